@@ -0,0 +1,257 @@
+// Package brand centralizes brand governance for enterprise users: a
+// BrandKit screens outbound prompts for banned words and a required
+// style suffix, then checks generated images against an approved color
+// palette and can composite a logo watermark onto them.
+package brand
+
+import (
+	"bytes"
+	"fmt"
+	stdimage "image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"math"
+	"strings"
+
+	reveimage "github.com/shamspias/reve-go/image"
+	"github.com/shamspias/reve-go/imagecache"
+	"github.com/shamspias/reve-go/types"
+)
+
+// Color is an RGB brand palette color.
+type Color struct {
+	R, G, B uint8
+}
+
+// BrandKit holds one brand's governance rules. The zero value has no
+// rules and Apply/Check/Overlay are no-ops.
+type BrandKit struct {
+	// Palette is the brand's approved colors. Check flags generations
+	// whose sampled pixels lie, on average, too far from their nearest
+	// palette entry.
+	Palette []Color
+
+	// MaxPaletteDistance is the largest average distance (0-441, the
+	// diagonal of the RGB cube) to the nearest palette color that Check
+	// tolerates before reporting a violation. Ignored when Palette is
+	// empty.
+	MaxPaletteDistance float64
+
+	// BannedWords are terms Apply rejects in a prompt, matched
+	// case-insensitively as substrings.
+	BannedWords []string
+
+	// StyleSuffix is appended to every prompt Apply processes, unless
+	// already present.
+	StyleSuffix string
+
+	// Logo, if set, is composited onto the bottom-right corner of every
+	// image Overlay processes.
+	Logo *types.Image
+
+	// LogoMargin is the padding, in pixels, kept between Logo and the
+	// image edges.
+	LogoMargin int
+
+	// LogoCache, if set, caches Logo's decoded form across Overlay
+	// calls, so compositing a logo onto every image in a batch decodes
+	// it once instead of once per call.
+	LogoCache *imagecache.Cache
+}
+
+// ErrBannedWord is returned by Apply when a prompt contains a banned term.
+type ErrBannedWord struct {
+	Word string
+}
+
+func (e *ErrBannedWord) Error() string {
+	return fmt.Sprintf("brand: prompt contains banned word %q", e.Word)
+}
+
+// Apply screens prompt for BannedWords and appends StyleSuffix, returning
+// the prompt to actually send.
+func (k *BrandKit) Apply(prompt string) (string, error) {
+	lower := strings.ToLower(prompt)
+	for _, word := range k.BannedWords {
+		if word == "" {
+			continue
+		}
+		if strings.Contains(lower, strings.ToLower(word)) {
+			return "", &ErrBannedWord{Word: word}
+		}
+	}
+
+	if k.StyleSuffix != "" && !strings.Contains(prompt, k.StyleSuffix) {
+		prompt = strings.TrimSpace(prompt) + ", " + k.StyleSuffix
+	}
+	return prompt, nil
+}
+
+// ApplyToCreate runs Apply against params.Prompt and, on success,
+// replaces it with the screened/suffixed prompt.
+func (k *BrandKit) ApplyToCreate(params *reveimage.CreateParams) error {
+	p, err := k.Apply(params.Prompt)
+	if err != nil {
+		return err
+	}
+	params.Prompt = p
+	return nil
+}
+
+// ApplyToEdit runs Apply against params.Instruction and, on success,
+// replaces it with the screened/suffixed instruction.
+func (k *BrandKit) ApplyToEdit(params *reveimage.EditParams) error {
+	p, err := k.Apply(params.Instruction)
+	if err != nil {
+		return err
+	}
+	params.Instruction = p
+	return nil
+}
+
+// ApplyToRemix runs Apply against params.Prompt and, on success,
+// replaces it with the screened/suffixed prompt.
+func (k *BrandKit) ApplyToRemix(params *reveimage.RemixParams) error {
+	p, err := k.Apply(params.Prompt)
+	if err != nil {
+		return err
+	}
+	params.Prompt = p
+	return nil
+}
+
+// Violation describes one way a generated image failed Check.
+type Violation struct {
+	Reason string
+}
+
+func (v Violation) Error() string {
+	return v.Reason
+}
+
+// Check decodes result's image and reports any palette violations
+// against k. An empty, non-nil return means the image passed.
+func (k *BrandKit) Check(result *types.Result) ([]Violation, error) {
+	data, err := result.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return k.checkImageBytes(data)
+}
+
+func (k *BrandKit) checkImageBytes(data []byte) ([]Violation, error) {
+	img, _, err := stdimage.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("brand: decode image: %w", err)
+	}
+
+	violations := []Violation{}
+	if len(k.Palette) > 0 {
+		if d := k.averagePaletteDistance(img); d > k.MaxPaletteDistance {
+			violations = append(violations, Violation{
+				Reason: fmt.Sprintf("average palette distance %.1f exceeds limit %.1f", d, k.MaxPaletteDistance),
+			})
+		}
+	}
+	return violations, nil
+}
+
+// averagePaletteDistance samples img on a coarse grid and returns the
+// average Euclidean RGB distance from each sample to its nearest
+// Palette color.
+func (k *BrandKit) averagePaletteDistance(img stdimage.Image) float64 {
+	const gridSize = 16
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return 0
+	}
+
+	var total float64
+	var samples int
+	for gy := 0; gy < gridSize; gy++ {
+		for gx := 0; gx < gridSize; gx++ {
+			x := bounds.Min.X + gx*width/gridSize
+			y := bounds.Min.Y + gy*height/gridSize
+			total += k.nearestDistance(img.At(x, y))
+			samples++
+		}
+	}
+	if samples == 0 {
+		return 0
+	}
+	return total / float64(samples)
+}
+
+func (k *BrandKit) nearestDistance(c color.Color) float64 {
+	r32, g32, b32, _ := c.RGBA()
+	r, g, b := float64(r32>>8), float64(g32>>8), float64(b32>>8)
+
+	best := math.MaxFloat64
+	for _, pc := range k.Palette {
+		dr := r - float64(pc.R)
+		dg := g - float64(pc.G)
+		db := b - float64(pc.B)
+		d := math.Sqrt(dr*dr + dg*dg + db*db)
+		if d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+// Overlay composites Logo onto data's bottom-right corner, padded by
+// LogoMargin, re-encoding the result as PNG. If Logo is nil, Overlay
+// returns data unchanged.
+func (k *BrandKit) Overlay(data []byte) ([]byte, error) {
+	if k.Logo == nil {
+		return data, nil
+	}
+
+	base, _, err := stdimage.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("brand: decode image: %w", err)
+	}
+
+	logoBytes, err := k.Logo.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("brand: read logo: %w", err)
+	}
+	logo, err := k.decodeLogo(logoBytes)
+	if err != nil {
+		return nil, fmt.Errorf("brand: decode logo: %w", err)
+	}
+
+	bounds := base.Bounds()
+	logoBounds := logo.Bounds()
+
+	dst := stdimage.NewRGBA(bounds)
+	draw.Draw(dst, bounds, base, bounds.Min, draw.Src)
+
+	origin := stdimage.Pt(
+		bounds.Max.X-logoBounds.Dx()-k.LogoMargin,
+		bounds.Max.Y-logoBounds.Dy()-k.LogoMargin,
+	)
+	target := stdimage.Rectangle{Min: origin, Max: origin.Add(logoBounds.Size())}
+	draw.Draw(dst, target, logo, logoBounds.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, fmt.Errorf("brand: encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeLogo decodes logoBytes through LogoCache when set, so
+// generating a large batch with the same Logo decodes it once instead
+// of once per Overlay call.
+func (k *BrandKit) decodeLogo(logoBytes []byte) (stdimage.Image, error) {
+	if k.LogoCache == nil {
+		img, _, err := stdimage.Decode(bytes.NewReader(logoBytes))
+		return img, err
+	}
+	return k.LogoCache.Decode(logoBytes)
+}
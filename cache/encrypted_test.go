@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shamspias/reve-go/types"
+)
+
+func fixedKeySource(key []byte) KeySource {
+	return func(ctx context.Context) ([]byte, error) {
+		return key, nil
+	}
+}
+
+func TestEncryptedFileCacheRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+
+	c, err := NewEncryptedFileCache(dir, fixedKeySource(key))
+	if err != nil {
+		t.Fatalf("NewEncryptedFileCache: %v", err)
+	}
+
+	want := &types.Result{RequestID: "req_1", CreditsUsed: 3}
+	if err := c.Set(context.Background(), "key1", want); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	got, ok, err := c.Get(context.Background(), "key1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !ok {
+		t.Fatal("Get() ok = false, want true")
+	}
+	if got.RequestID != want.RequestID || got.CreditsUsed != want.CreditsUsed {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+}
+
+func TestEncryptedFileCacheMissReturnsFalse(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewEncryptedFileCache(dir, fixedKeySource(make([]byte, 32)))
+	if err != nil {
+		t.Fatalf("NewEncryptedFileCache: %v", err)
+	}
+
+	_, ok, err := c.Get(context.Background(), "missing")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if ok {
+		t.Error("Get() ok = true, want false for a missing key")
+	}
+}
+
+func TestEncryptedFileCacheStoresCiphertextNotPlaintext(t *testing.T) {
+	dir := t.TempDir()
+	key := make([]byte, 32)
+	c, err := NewEncryptedFileCache(dir, fixedKeySource(key))
+	if err != nil {
+		t.Fatalf("NewEncryptedFileCache: %v", err)
+	}
+
+	secretPrompt := "a very specific secret prompt that must not appear on disk in the clear"
+	if err := c.Set(context.Background(), "key1", &types.Result{RequestID: secretPrompt}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "key1.enc"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if bytes.Contains(raw, []byte(secretPrompt)) {
+		t.Error("on-disk entry contains the plaintext prompt, want it sealed with AES-GCM")
+	}
+}
+
+func TestEncryptedFileCacheWrongKeyFailsToOpen(t *testing.T) {
+	dir := t.TempDir()
+	key1 := make([]byte, 32)
+	key2 := make([]byte, 32)
+	key2[0] = 1
+
+	c1, err := NewEncryptedFileCache(dir, fixedKeySource(key1))
+	if err != nil {
+		t.Fatalf("NewEncryptedFileCache: %v", err)
+	}
+	if err := c1.Set(context.Background(), "key1", &types.Result{RequestID: "req_1"}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	c2, err := NewEncryptedFileCache(dir, fixedKeySource(key2))
+	if err != nil {
+		t.Fatalf("NewEncryptedFileCache: %v", err)
+	}
+	if _, _, err := c2.Get(context.Background(), "key1"); err == nil {
+		t.Error("Get() with the wrong key succeeded, want a decryption error")
+	}
+}
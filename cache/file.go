@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/shamspias/reve-go/image"
+	"github.com/shamspias/reve-go/types"
+)
+
+// FileCache is a filesystem-backed image.Cache that persists each result
+// as one JSON file under Dir, so the cache survives process restarts.
+// Install it with reve.WithCache.
+type FileCache struct {
+	Dir string
+}
+
+// NewFileCache creates a FileCache rooted at dir, creating it if missing.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileCache{Dir: dir}, nil
+}
+
+// Get implements image.Cache.
+func (c *FileCache) Get(_ context.Context, key string) (*types.Result, bool, error) {
+	data, err := os.ReadFile(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var result types.Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, false, err
+	}
+	return &result, true, nil
+}
+
+// Set implements image.Cache.
+func (c *FileCache) Set(_ context.Context, key string, result *types.Result) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path(key), data, 0o644)
+}
+
+func (c *FileCache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+var _ image.Cache = (*FileCache)(nil)
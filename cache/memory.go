@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/shamspias/reve-go/image"
+	"github.com/shamspias/reve-go/types"
+)
+
+// MemoryLRU is an in-process image.Cache that evicts the least recently
+// used entry once it holds more than Capacity results. It implements
+// image.Cache; install it with reve.WithCache.
+type MemoryLRU struct {
+	capacity int
+
+	mu      sync.Mutex
+	ll      *list.List // front = most recently used
+	entries map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key    string
+	result *types.Result
+}
+
+// NewMemoryLRU creates a MemoryLRU holding at most capacity results.
+// A non-positive capacity means unbounded.
+func NewMemoryLRU(capacity int) *MemoryLRU {
+	return &MemoryLRU{
+		capacity: capacity,
+		ll:       list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get implements image.Cache.
+func (c *MemoryLRU) Get(_ context.Context, key string) (*types.Result, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	c.ll.MoveToFront(elem)
+	return elem.Value.(*memoryEntry).result, true, nil
+}
+
+// Set implements image.Cache.
+func (c *MemoryLRU) Set(_ context.Context, key string, result *types.Result) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.ll.MoveToFront(elem)
+		elem.Value.(*memoryEntry).result = result
+		return nil
+	}
+
+	elem := c.ll.PushFront(&memoryEntry{key: key, result: result})
+	c.entries[key] = elem
+
+	if c.capacity > 0 && c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.entries, oldest.Value.(*memoryEntry).key)
+		}
+	}
+	return nil
+}
+
+var _ image.Cache = (*MemoryLRU)(nil)
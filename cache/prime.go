@@ -0,0 +1,114 @@
+// Package cache provides deploy-time priming of a known set of prompts
+// into a cache or storage backend, so a site's standard imagery exists
+// before the first visitor requests it instead of being generated (and
+// paid for) on demand.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/shamspias/reve-go/image"
+	"github.com/shamspias/reve-go/types"
+)
+
+// Store persists a generated image under a content-derived key and
+// reports whether a key is already present. Implementations back onto
+// whatever storage a deployment already uses (disk, S3, a CDN origin).
+type Store interface {
+	Has(ctx context.Context, key string) (bool, error)
+	Put(ctx context.Context, key string, data []byte) error
+}
+
+// ManifestEntry is one prompt Prime ensures exists in a Store.
+type ManifestEntry struct {
+	// Prompt is the text description to generate.
+	Prompt string
+
+	// Seed pins the generation, so re-running Prime is idempotent. See
+	// image.CreateParams.Seed.
+	Seed int64
+
+	// AspectRatio is the desired aspect ratio.
+	AspectRatio types.AspectRatio
+}
+
+// Key returns entry's stable cache key, derived from its prompt, seed,
+// and aspect ratio.
+func (e ManifestEntry) Key() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d\x00%s", e.Prompt, e.Seed, e.AspectRatio)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Report summarizes a Prime run.
+type Report struct {
+	// Primed lists the keys that were missing and got generated.
+	Primed []string
+
+	// Skipped lists the keys that already existed in the store.
+	Skipped []string
+
+	// Errors maps a key to the error that stopped it from being primed.
+	Errors map[string]error
+}
+
+// Prime ensures every entry in manifest exists in store, generating and
+// storing only the ones missing. It keeps going past individual
+// generation failures, collecting them in Report.Errors, so one bad
+// prompt in a large manifest doesn't abort the run; it returns a non-nil
+// error only when ctx is canceled.
+//
+// Example:
+//
+//	report, err := cache.Prime(ctx, client.Images, store, []cache.ManifestEntry{
+//		{Prompt: "hero banner, minimalist", Seed: 1, AspectRatio: types.Ratio16x9},
+//		{Prompt: "empty cart illustration", Seed: 2, AspectRatio: types.Ratio1x1},
+//	})
+func Prime(ctx context.Context, svc *image.Service, store Store, manifest []ManifestEntry) (*Report, error) {
+	report := &Report{Errors: make(map[string]error)}
+
+	for _, entry := range manifest {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		key := entry.Key()
+		exists, err := store.Has(ctx, key)
+		if err != nil {
+			report.Errors[key] = err
+			continue
+		}
+		if exists {
+			report.Skipped = append(report.Skipped, key)
+			continue
+		}
+
+		if err := primeOne(ctx, svc, store, entry, key); err != nil {
+			report.Errors[key] = err
+			continue
+		}
+		report.Primed = append(report.Primed, key)
+	}
+
+	return report, nil
+}
+
+func primeOne(ctx context.Context, svc *image.Service, store Store, entry ManifestEntry, key string) error {
+	result, err := svc.Create(ctx, &image.CreateParams{
+		Prompt:      entry.Prompt,
+		Seed:        entry.Seed,
+		AspectRatio: entry.AspectRatio,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := result.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return store.Put(ctx, key, data)
+}
@@ -1,11 +1,16 @@
 package reve
 
 import (
+	"context"
+	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/shamspias/reve-go/image"
 	"github.com/shamspias/reve-go/internal/transport"
+	"github.com/shamspias/reve-go/simulate"
+	"github.com/shamspias/reve-go/types"
 )
 
 // Default configuration values.
@@ -23,7 +28,13 @@ type Client struct {
 	// Images provides image generation operations.
 	Images *image.Service
 
-	config *Config
+	// configMu guards config against a concurrent Reload while Config is
+	// read; it does not guard the fields image.Service.Reload passes
+	// straight through to the transport, which has its own locking (see
+	// transport.Client.Reload).
+	configMu sync.Mutex
+	config   *Config
+	history  *historyBuffer
 }
 
 // Config holds client configuration.
@@ -34,12 +45,196 @@ type Config struct {
 	MaxRetries   int
 	RetryMinWait time.Duration
 	RetryMaxWait time.Duration
-	UserAgent    string
-	Debug        bool
-	Logger       func(format string, args ...any)
-	Transport    http.RoundTripper
+
+	// BackoffPolicy overrides the default capped-exponential-with-jitter
+	// wait between retries. See WithBackoffPolicy.
+	BackoffPolicy transport.BackoffPolicy
+
+	// RetryBudget, if set, is shared across every request the client
+	// makes, capping what fraction of total traffic may be retries
+	// instead of letting each failing request retry independently. See
+	// WithRetryBudget.
+	RetryBudget *transport.RetryBudget
+
+	UserAgent  string
+	Debug      bool
+	Logger     func(format string, args ...any)
+	SlogLogger *slog.Logger
+	Transport  http.RoundTripper
+
+	CircuitThreshold int
+	CircuitCooldown  time.Duration
+
+	HedgeDelay     time.Duration
+	HedgeMaxHedges int
+
+	// binaryUploads, set by WithBinaryUploads, sends reference images as
+	// multipart/form-data file parts instead of base64 JSON.
+	binaryUploads bool
+
+	// compression, set by WithCompression, gzip-compresses JSON request
+	// bodies.
+	compression bool
+
+	// errorBodyCapture, set by WithErrorBodyCapture, attaches a redacted
+	// request body to APIError.RequestBody for 4xx responses.
+	errorBodyCapture bool
+
+	// keys holds the rotation pool set by NewClientWithKeys; nil when the
+	// client uses a single static APIKey.
+	keys *transport.KeyRotator
+
+	// keyProvider holds the dynamic credential source set by
+	// WithKeyProvider; when set it overrides APIKey and keys.
+	keyProvider KeyProvider
+
+	// contentFilter holds the local pre-screening hook set by
+	// WithContentFilter.
+	contentFilter ContentFilter
+
+	// fallback holds the graceful-degradation hook set by WithFallback.
+	fallback Fallback
+
+	// cache holds the response cache set by WithCache.
+	cache Cache
+
+	// flags holds the runtime feature-flag source set by WithFeatureFlags.
+	flags FlagProvider
+
+	// singleflight enables in-flight request deduplication, set by
+	// WithSingleflight.
+	singleflight bool
+
+	// quota holds the credit budget partitioner set by WithQuotaManager.
+	quota QuotaManager
+
+	// promptScrubber holds the PII redaction hook set by
+	// WithPromptScrubber.
+	promptScrubber PromptScrubber
+
+	// capabilities holds the live model-capability source set by
+	// WithCapabilityProvider.
+	capabilities CapabilityProvider
+
+	// events holds the observability sink set by WithEventSink.
+	events EventSink
+
+	// autoDownscale and autoDownscaleMegapixels are set by
+	// WithAutoDownscale.
+	autoDownscale           bool
+	autoDownscaleMegapixels float64
+
+	// region and regionEndpoints hold the data-residency selection made
+	// by WithRegion and WithRegionEndpoints.
+	region          string
+	regionEndpoints map[string]string
+
+	// minDeadlineMargin, set by WithMinDeadlineMargin, rejects a call up
+	// front with a *transport.DeadlineError when its context has too
+	// little time left to be worth attempting.
+	minDeadlineMargin time.Duration
+
+	// maxConcurrentRequests, set by WithMaxConcurrentRequests, caps how
+	// many requests this client runs at once, shedding the rest with a
+	// *transport.RateLimitShedError instead of queuing them.
+	maxConcurrentRequests int
 }
 
+// ContentFilter screens a prompt locally before it is sent to the API.
+// See WithContentFilter.
+type ContentFilter = image.ContentFilter
+
+// Fallback produces a degraded *types.Result when Create, Edit, or
+// Remix fail after exhausting retries. See WithFallback.
+type Fallback = image.Fallback
+
+// Cache stores Create/Edit/Remix results keyed by a canonical hash of
+// their params, so re-running a batch script doesn't re-spend credits
+// on an identical request. See WithCache and package cache for bundled
+// implementations.
+type Cache = image.Cache
+
+// FlagProvider is consulted before every Create, Edit, and Remix call,
+// letting operators change behavior at runtime without redeploying
+// callers. See WithFeatureFlags.
+type FlagProvider = image.FlagProvider
+
+// Operation identifies a Create, Edit, or Remix call for FlagProvider
+// checks that apply to only one operation.
+type Operation = image.Operation
+
+// PromptScrubber redacts PII from a prompt or edit instruction before
+// it's screened, cached, or included in diagnostics. See
+// WithPromptScrubber.
+type PromptScrubber = image.PromptScrubber
+
+// Operations a FlagProvider can be consulted about.
+const (
+	OpCreate = image.OpCreate
+	OpEdit   = image.OpEdit
+	OpRemix  = image.OpRemix
+)
+
+// QuotaManager partitions the client's credit budget across named
+// shares. See WithQuotaManager and package quota for a bundled
+// implementation.
+type QuotaManager = image.QuotaManager
+
+// CapabilityProvider reports what a model version actually supports, so
+// Create, Edit, and Remix can reject a param a version doesn't accept
+// before spending a round trip on it. See WithCapabilityProvider.
+type CapabilityProvider = image.CapabilityProvider
+
+// ModelCapabilities describes what a specific model version supports,
+// as reported by a CapabilityProvider.
+type ModelCapabilities = image.ModelCapabilities
+
+// Event is a notable occurrence worth forwarding to an observability or
+// SIEM pipeline: a content policy violation, a quota partition running
+// out of budget, or the circuit breaker opening. See WithEventSink.
+type Event = image.Event
+
+// EventSink receives Event values as they happen. See the otellog
+// package for an OTel Log Data Model-shaped implementation, and
+// WithEventSink to install one.
+type EventSink = image.EventSink
+
+// SimProfile configures WithSimulation's mock latency, failure rate, and
+// credit accounting.
+type SimProfile = simulate.Profile
+
+// KeyProvider fetches an API key on demand, e.g. from Vault or AWS
+// Secrets Manager, so credentials can be rotated or revoked without
+// recreating the client. See WithKeyProvider.
+type KeyProvider = transport.KeyProvider
+
+// RotationPolicy controls how NewClientWithKeys cycles a client across
+// multiple API keys.
+type RotationPolicy = transport.RotationPolicy
+
+// Reason is implemented by every error Create, Edit, or Remix return when
+// the client aborts a call itself rather than getting a response back --
+// a tripped circuit breaker, an exhausted retry budget, a context deadline
+// too tight to admit, or shed load under WithMaxConcurrentRequests -- so
+// callers can show an accurate, specific message instead of a generic
+// "request failed":
+//
+//	var reason reve.Reason
+//	if errors.As(err, &reason) {
+//		log.Printf("aborted: %s", reason.Reason())
+//	}
+type Reason = transport.Reason
+
+const (
+	// RoundRobin spreads every request across the key set in turn.
+	RoundRobin = transport.RoundRobin
+
+	// Failover sticks with the current key until it hits
+	// INSUFFICIENT_CREDITS or RATE_LIMIT_EXCEEDED, then advances and
+	// retries on the next key.
+	Failover = transport.Failover
+)
+
 // NewClient creates a new Reve API client.
 //
 // Example:
@@ -77,26 +272,185 @@ func NewClient(apiKey string, opts ...Option) *Client {
 		opt(config)
 	}
 
+	return newClient(config)
+}
+
+// NewClientWithKeys creates a client that spreads or fails over its
+// requests across a pool of API keys instead of a single one, for teams
+// sharing several project keys who want to maximize throughput or
+// tolerate one key running out of credits.
+//
+// Example:
+//
+//	client := reve.NewClientWithKeys(
+//		[]string{"key-1", "key-2", "key-3"},
+//		reve.Failover,
+//	)
+func NewClientWithKeys(keys []string, policy RotationPolicy, opts ...Option) *Client {
+	config := &Config{
+		BaseURL:      DefaultBaseURL,
+		Timeout:      DefaultTimeout,
+		MaxRetries:   DefaultMaxRetries,
+		RetryMinWait: DefaultRetryMinWait,
+		RetryMaxWait: DefaultRetryMaxWait,
+		UserAgent:    DefaultUserAgent,
+		keys:         transport.NewKeyRotator(keys, policy),
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return newClient(config)
+}
+
+func newClient(config *Config) *Client {
+	history := newHistoryBuffer(supportBundleHistoryLimit)
+
+	if config.region != "" {
+		endpoints := config.regionEndpoints
+		if endpoints == nil {
+			endpoints = DefaultRegionEndpoints
+		}
+		if url, ok := endpoints[config.region]; ok {
+			config.BaseURL = url
+		}
+	}
+
 	t := transport.New(&transport.Config{
-		BaseURL:      config.BaseURL,
-		APIKey:       config.APIKey,
-		UserAgent:    config.UserAgent,
-		Timeout:      config.Timeout,
-		MaxRetries:   config.MaxRetries,
-		RetryMinWait: config.RetryMinWait,
-		RetryMaxWait: config.RetryMaxWait,
-		Debug:        config.Debug,
-		Logger:       config.Logger,
-		Transport:    config.Transport,
+		BaseURL:               config.BaseURL,
+		APIKey:                config.APIKey,
+		Keys:                  config.keys,
+		KeyProvider:           config.keyProvider,
+		UserAgent:             config.UserAgent,
+		Timeout:               config.Timeout,
+		MaxRetries:            config.MaxRetries,
+		RetryMinWait:          config.RetryMinWait,
+		RetryMaxWait:          config.RetryMaxWait,
+		BackoffPolicy:         config.BackoffPolicy,
+		RetryBudget:           config.RetryBudget,
+		Debug:                 config.Debug,
+		Logger:                config.Logger,
+		SlogLogger:            config.SlogLogger,
+		Transport:             config.Transport,
+		CircuitThreshold:      config.CircuitThreshold,
+		CircuitCooldown:       config.CircuitCooldown,
+		HedgeDelay:            config.HedgeDelay,
+		HedgeMaxHedges:        config.HedgeMaxHedges,
+		BinaryUploads:         config.binaryUploads,
+		Compression:           config.compression,
+		CaptureErrorBody:      config.errorBodyCapture,
+		MinDeadlineMargin:     config.minDeadlineMargin,
+		MaxConcurrentRequests: config.maxConcurrentRequests,
+		HistoryRecorder:       history.record,
 	})
 
+	svc := image.NewService(t)
+	if config.contentFilter != nil {
+		svc.SetContentFilter(config.contentFilter)
+	}
+	if config.fallback != nil {
+		svc.SetFallback(config.fallback)
+	}
+	if config.cache != nil {
+		svc.SetCache(config.cache)
+	}
+	if config.flags != nil {
+		svc.SetFlags(config.flags)
+	}
+	if config.singleflight {
+		svc.SetSingleflight(true)
+	}
+	if config.quota != nil {
+		svc.SetQuotaManager(config.quota)
+	}
+	if config.promptScrubber != nil {
+		svc.SetPromptScrubber(config.promptScrubber)
+	}
+	if config.region != "" {
+		svc.SetRegion(config.region)
+	}
+	if config.capabilities != nil {
+		svc.SetCapabilityProvider(config.capabilities)
+	}
+	if config.events != nil {
+		svc.SetEventSink(config.events)
+	}
+	if config.autoDownscale {
+		svc.SetAutoDownscale(config.autoDownscaleMegapixels)
+	}
+
 	return &Client{
-		Images: image.NewService(t),
-		config: config,
+		Images:  svc,
+		config:  config,
+		history: history,
 	}
 }
 
 // Config returns a copy of the client configuration.
 func (c *Client) Config() Config {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
 	return *c.config
 }
+
+// ReloadConfig carries the subset of Config that Reload can swap on a
+// live Client: retry policy, the circuit breaker, hedging, deadline
+// admission, and the concurrency cap. It deliberately excludes
+// connection-level settings (APIKey, BaseURL, Timeout, Transport) and
+// anything installed through a Set* method on Images (cache, fallback,
+// feature flags, quota manager, and the rest) -- changing those under
+// in-flight requests would change what a call already under way means,
+// not just how resilient it is. See transport.Client.Reload.
+type ReloadConfig = transport.ReloadConfig
+
+// Reload atomically swaps this client's retry policy, circuit breaker,
+// hedging, deadline margin, and concurrency cap for the settings in cfg,
+// without dropping in-flight requests: each Create, Edit, or Remix call
+// already under way finishes using the settings it started with, and
+// only calls starting after Reload returns see the new ones. This lets
+// an operator tighten retries, open a breaker back up, or shed load
+// during an incident without restarting the process.
+//
+// Reload does not touch the cache, fallback, feature flags, quota
+// manager, or any other hook installed via a With* option or Images.Set*
+// method -- reinstall those directly if they need to change. It also
+// does not update reve-go's own default params (Version, AspectRatio,
+// and similar); the SDK has no live default-params store to reload in
+// the first place, so a caller building those from a config file (see
+// package reveconfig) sets them explicitly on each CreateParams instead.
+func (c *Client) Reload(cfg *ReloadConfig) {
+	c.Images.Reload(cfg)
+
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	c.config.MaxRetries = cfg.MaxRetries
+	c.config.RetryMinWait = cfg.RetryMinWait
+	c.config.RetryMaxWait = cfg.RetryMaxWait
+	c.config.BackoffPolicy = cfg.BackoffPolicy
+	c.config.RetryBudget = cfg.RetryBudget
+	c.config.CircuitThreshold = cfg.CircuitThreshold
+	c.config.CircuitCooldown = cfg.CircuitCooldown
+	c.config.HedgeDelay = cfg.HedgeDelay
+	c.config.HedgeMaxHedges = cfg.HedgeMaxHedges
+	c.config.minDeadlineMargin = cfg.MinDeadlineMargin
+	c.config.maxConcurrentRequests = cfg.MaxConcurrentRequests
+}
+
+// Capabilities reports what version actually supports — which aspect
+// ratios, how many NumImages, and which postprocessing combinations —
+// per the CapabilityProvider installed with WithCapabilityProvider.
+// Returns ok=false if no provider is installed or it doesn't recognize
+// version.
+func (c *Client) Capabilities(ctx context.Context, version types.ModelVersion) (ModelCapabilities, bool) {
+	return c.Images.Capabilities(ctx, version)
+}
+
+// CreditsRemaining returns the CreditsRemaining reported by the most
+// recent Create, Edit, or Remix response, and when it was recorded.
+// The zero time means no response has come back yet. Lets dashboards
+// and admission control read the balance without spending an API call
+// to check it.
+func (c *Client) CreditsRemaining() (int, time.Time) {
+	return c.Images.CreditsRemaining()
+}
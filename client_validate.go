@@ -0,0 +1,95 @@
+package reve
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// NewClientE is like NewClient, but validates every option before
+// constructing the client, returning a descriptive error for a
+// negative timeout, an empty API key, a malformed base URL, or another
+// nonsensical value, instead of NewClient's behavior of accepting
+// anything and surfacing the problem lazily on the first request.
+//
+// Example:
+//
+//	client, err := reve.NewClientE(apiKey, reve.WithTimeout(-1))
+//	// err: "reve: invalid config: Timeout: must be positive (got -1ns)"
+func NewClientE(apiKey string, opts ...Option) (*Client, error) {
+	config := &Config{
+		APIKey:       apiKey,
+		BaseURL:      DefaultBaseURL,
+		Timeout:      DefaultTimeout,
+		MaxRetries:   DefaultMaxRetries,
+		RetryMinWait: DefaultRetryMinWait,
+		RetryMaxWait: DefaultRetryMaxWait,
+		UserAgent:    DefaultUserAgent,
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return newClient(config), nil
+}
+
+// configFieldError describes one invalid Config field.
+type configFieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e *configFieldError) Error() string {
+	return fmt.Sprintf("reve: invalid config: %s: %s", e.Field, e.Reason)
+}
+
+// validate checks c for values that can be rejected at construction
+// time rather than discovered on the first failed request, collecting
+// every problem found via errors.Join instead of stopping at the first.
+func (c *Config) validate() error {
+	var errs []error
+
+	if c.APIKey == "" && c.keys == nil && c.keyProvider == nil {
+		errs = append(errs, &configFieldError{"APIKey", "required (or use NewClientWithKeys or WithKeyProvider)"})
+	}
+	if c.BaseURL != "" {
+		u, err := url.Parse(c.BaseURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, &configFieldError{"BaseURL", fmt.Sprintf("must be an absolute URL (got %q)", c.BaseURL)})
+		}
+	}
+	if c.Timeout < 0 {
+		errs = append(errs, &configFieldError{"Timeout", fmt.Sprintf("must be positive (got %s)", c.Timeout)})
+	}
+	if c.MaxRetries < 0 {
+		errs = append(errs, &configFieldError{"MaxRetries", fmt.Sprintf("must not be negative (got %d)", c.MaxRetries)})
+	}
+	if c.RetryMinWait < 0 {
+		errs = append(errs, &configFieldError{"RetryMinWait", fmt.Sprintf("must not be negative (got %s)", c.RetryMinWait)})
+	}
+	if c.RetryMaxWait < 0 {
+		errs = append(errs, &configFieldError{"RetryMaxWait", fmt.Sprintf("must not be negative (got %s)", c.RetryMaxWait)})
+	}
+	if c.RetryMinWait > 0 && c.RetryMaxWait > 0 && c.RetryMinWait > c.RetryMaxWait {
+		errs = append(errs, &configFieldError{"RetryMinWait", fmt.Sprintf("must not exceed RetryMaxWait (%s > %s)", c.RetryMinWait, c.RetryMaxWait)})
+	}
+	if c.CircuitThreshold < 0 {
+		errs = append(errs, &configFieldError{"CircuitThreshold", fmt.Sprintf("must not be negative (got %d)", c.CircuitThreshold)})
+	}
+	if c.CircuitCooldown < 0 {
+		errs = append(errs, &configFieldError{"CircuitCooldown", fmt.Sprintf("must not be negative (got %s)", c.CircuitCooldown)})
+	}
+	if c.HedgeDelay < 0 {
+		errs = append(errs, &configFieldError{"HedgeDelay", fmt.Sprintf("must not be negative (got %s)", c.HedgeDelay)})
+	}
+	if c.HedgeMaxHedges < 0 {
+		errs = append(errs, &configFieldError{"HedgeMaxHedges", fmt.Sprintf("must not be negative (got %d)", c.HedgeMaxHedges)})
+	}
+
+	return errors.Join(errs...)
+}
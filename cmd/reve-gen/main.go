@@ -0,0 +1,121 @@
+// Command reve-gen regenerates the ErrorCode catalog in
+// internal/transport/errors.go from Reve's OpenAPI spec, so a new error
+// code the API starts returning shows up as a named constant without a
+// manual edit:
+//
+//	reve-gen -spec openapi.json -out internal/transport/errorcodes_generated.go
+//
+// Reve does not publish a stable OpenAPI spec as of this writing, so
+// this tool only covers the error-code catalog: the one piece of the
+// generated surface that's just a closed enum and safe to regenerate
+// mechanically. CreateParams, EditParams, RemixParams, and the result
+// types are NOT generated here -- they carry hand-written Validate
+// logic, Lint heuristics, and doc comments (length limits, defaults,
+// worked examples) that a round trip through a generator would
+// overwrite or have to awkwardly preserve via annotations. Once Reve
+// publishes a spec stable enough to justify that machinery, this tool
+// is the place to extend it; until then, editing those structs by hand
+// stays the honest choice.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+	"strings"
+)
+
+// openAPISpec is the minimal subset of an OpenAPI 3 document this tool
+// reads: just enough to find the ErrorCode enum schema, not a general
+// OpenAPI model.
+type openAPISpec struct {
+	Components struct {
+		Schemas map[string]struct {
+			Enum []string `json:"enum"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func main() {
+	spec := flag.String("spec", "", "path to Reve's OpenAPI spec (JSON)")
+	out := flag.String("out", "", "output Go file path (default: stdout)")
+	schema := flag.String("schema", "ErrorCode", "name of the enum schema to generate constants for")
+	flag.Parse()
+
+	if *spec == "" {
+		fmt.Fprintln(os.Stderr, "reve-gen: -spec is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*spec, *out, *schema); err != nil {
+		fmt.Fprintf(os.Stderr, "reve-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, outPath, schemaName string) error {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return fmt.Errorf("read spec: %w", err)
+	}
+
+	var doc openAPISpec
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parse spec: %w", err)
+	}
+
+	schemaDef, ok := doc.Components.Schemas[schemaName]
+	if !ok || len(schemaDef.Enum) == 0 {
+		return fmt.Errorf("spec has no enum schema named %q", schemaName)
+	}
+
+	src := generate(schemaDef.Enum)
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return fmt.Errorf("format generated source: %w", err)
+	}
+
+	if outPath == "" {
+		_, err = os.Stdout.Write(formatted)
+		return err
+	}
+	return os.WriteFile(outPath, formatted, 0o644)
+}
+
+// generate produces a Go source file declaring one ErrorCode constant
+// per value in codes, named by upper-snake-casing the API's own code
+// string (e.g. "NEW_ERROR_CODE" -> ErrCodeNewErrorCode).
+func generate(codes []string) string {
+	sorted := append([]string{}, codes...)
+	sort.Strings(sorted)
+
+	var b strings.Builder
+	b.WriteString("// Code generated by reve-gen from Reve's OpenAPI spec. DO NOT EDIT.\n\n")
+	b.WriteString("package transport\n\n")
+	b.WriteString("// Error codes generated from the OpenAPI spec's ErrorCode enum.\n")
+	b.WriteString("const (\n")
+	for _, code := range sorted {
+		fmt.Fprintf(&b, "\t%s ErrorCode = %q\n", constName(code), code)
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
+// constName turns an API error code like "NEW_ERROR_CODE" into a Go
+// constant name in this package's existing ErrCodeXxx style.
+func constName(code string) string {
+	var b strings.Builder
+	b.WriteString("ErrCode")
+	for _, word := range strings.Split(code, "_") {
+		if word == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]))
+		b.WriteString(strings.ToLower(word[1:]))
+	}
+	return b.String()
+}
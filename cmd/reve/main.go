@@ -0,0 +1,383 @@
+// Command reve is a thin CLI wrapper around the reve-go SDK, for
+// scripting image generation from a shell or CI job without writing
+// Go:
+//
+//	reve create --prompt "a red fox in snow" --ratio 16:9 -o fox.png
+//	reve edit --ref fox.png --instruction "make it winter" -o fox_winter.png
+//	reve remix --ref a.png --ref b.png --prompt "blend these styles" -o blend.png
+//	reve batch -f prompts.txt -o out
+//	reve login
+//
+// The API key is read from the REVE_API_KEY environment variable, or
+// the -api-key flag. Run "reve login" once to save a key to the OS
+// keychain instead, then pass -keychain to other commands.
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	reve "github.com/shamspias/reve-go"
+	"github.com/shamspias/reve-go/credentials"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "create":
+		err = runCreate(os.Args[2:])
+	case "edit":
+		err = runEdit(os.Args[2:])
+	case "remix":
+		err = runRemix(os.Args[2:])
+	case "batch":
+		err = runBatch(os.Args[2:])
+	case "login":
+		err = runLogin(os.Args[2:])
+	case "-h", "--help", "help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "reve: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reve: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage: reve <command> [flags]
+
+commands:
+  create   generate an image from a text prompt
+  edit     modify an existing image with a text instruction
+  remix    combine reference images with a text prompt
+  batch    run create for every prompt in a file
+  login    save an API key to the OS keychain
+
+Set REVE_API_KEY, or pass -api-key, to authenticate. Run "reve login"
+once to store a key in the OS keychain instead, then pass -keychain.`)
+}
+
+// keychainService and keychainAccount identify where "reve login"
+// stores a key and where -keychain looks it up.
+const (
+	keychainService = "reve-go"
+	keychainAccount = "default"
+)
+
+// clientFlags are the flags shared by every subcommand for constructing
+// a client.
+type clientFlags struct {
+	apiKey   string
+	baseURL  string
+	timeout  time.Duration
+	keychain bool
+}
+
+func (f *clientFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.apiKey, "api-key", os.Getenv("REVE_API_KEY"), "Reve API key (default: $REVE_API_KEY)")
+	fs.BoolVar(&f.keychain, "keychain", false, "read the API key saved by \"reve login\" from the OS keychain")
+	fs.StringVar(&f.baseURL, "base-url", "", "override the API base URL")
+	fs.DurationVar(&f.timeout, "timeout", reve.DefaultTimeout, "request timeout")
+}
+
+func (f *clientFlags) newClient() (*reve.Client, error) {
+	opts := []reve.Option{reve.WithTimeout(f.timeout)}
+	if f.baseURL != "" {
+		opts = append(opts, reve.WithBaseURL(f.baseURL))
+	}
+
+	if f.apiKey == "" && f.keychain {
+		provider, err := credentials.KeyProvider(keychainService, keychainAccount)
+		if err != nil {
+			return nil, fmt.Errorf("read key from keychain: %w", err)
+		}
+		return reve.NewClient("", append(opts, reve.WithKeyProvider(provider))...), nil
+	}
+	if f.apiKey == "" {
+		return nil, fmt.Errorf("an API key is required: set REVE_API_KEY, pass -api-key, or run \"reve login\" and pass -keychain")
+	}
+	return reve.NewClient(f.apiKey, opts...), nil
+}
+
+// runLogin prompts for an API key and saves it to the OS keychain via
+// credentials.Store, so scripts can authenticate with -keychain instead
+// of a plaintext key in a shell profile. Reve has no OAuth or
+// device-code token issuance endpoint for this to exchange a browser
+// login for a short-lived token against, so this stores the long-lived
+// API key itself rather than a session token.
+func runLogin(args []string) error {
+	fs := flag.NewFlagSet("login", flag.ExitOnError)
+	key := fs.String("key", "", "API key to store (default: prompt on stdin)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	apiKey := *key
+	if apiKey == "" {
+		fmt.Fprint(os.Stderr, "Enter your Reve API key: ")
+		reader := bufio.NewReader(os.Stdin)
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("read API key: %w", err)
+		}
+		apiKey = strings.TrimSpace(line)
+	}
+	if apiKey == "" {
+		return fmt.Errorf("no API key provided")
+	}
+
+	store, err := credentials.NewKeychainStore()
+	if err != nil {
+		return err
+	}
+	if err := store.Set(keychainService, keychainAccount, apiKey); err != nil {
+		return fmt.Errorf("save key to keychain: %w", err)
+	}
+
+	fmt.Println("API key saved. Pass -keychain to other commands to use it.")
+	return nil
+}
+
+func runCreate(args []string) error {
+	fs := flag.NewFlagSet("create", flag.ExitOnError)
+	cf := &clientFlags{}
+	cf.register(fs)
+	prompt := fs.String("prompt", "", "text description of the image (required)")
+	ratio := fs.String("ratio", "", "aspect ratio, e.g. 16:9, 1:1, auto")
+	version := fs.String("version", "", "model version override")
+	seed := fs.Int64("seed", 0, "random seed for reproducible output")
+	output := fs.String("o", "output.png", "output file path")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *prompt == "" {
+		return fmt.Errorf("-prompt is required")
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+
+	params := &reve.CreateParams{
+		Prompt:      *prompt,
+		AspectRatio: reve.AspectRatio(*ratio),
+		Version:     reve.ModelVersion(*version),
+		Seed:        *seed,
+	}
+	printLintWarnings(params.Lint())
+
+	result, err := client.Images.Create(context.Background(), params)
+	if err != nil {
+		return err
+	}
+	if err := result.SaveTo(*output); err != nil {
+		return err
+	}
+	fmt.Printf("saved %s (credits used: %d, seed: %d)\n", *output, result.CreditsUsed, result.Seed)
+	return nil
+}
+
+func runEdit(args []string) error {
+	fs := flag.NewFlagSet("edit", flag.ExitOnError)
+	cf := &clientFlags{}
+	cf.register(fs)
+	ref := fs.String("ref", "", "path to the reference image to edit (required)")
+	instruction := fs.String("instruction", "", "text instruction describing the edit (required)")
+	ratio := fs.String("ratio", "", "aspect ratio override")
+	output := fs.String("o", "output.png", "output file path")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *ref == "" || *instruction == "" {
+		return fmt.Errorf("-ref and -instruction are required")
+	}
+
+	img, err := reve.NewImageFromFile(*ref)
+	if err != nil {
+		return fmt.Errorf("read reference image: %w", err)
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+
+	params := &reve.EditParams{
+		Instruction:    *instruction,
+		ReferenceImage: img.Base64(),
+		AspectRatio:    reve.AspectRatio(*ratio),
+	}
+	printLintWarnings(params.Lint())
+
+	result, err := client.Images.Edit(context.Background(), params)
+	if err != nil {
+		return err
+	}
+	if err := result.SaveTo(*output); err != nil {
+		return err
+	}
+	fmt.Printf("saved %s (credits used: %d)\n", *output, result.CreditsUsed)
+	return nil
+}
+
+// refFlags collects repeated -ref flags, e.g. "-ref a.png -ref b.png".
+type refFlags []string
+
+func (r *refFlags) String() string     { return fmt.Sprint([]string(*r)) }
+func (r *refFlags) Set(v string) error { *r = append(*r, v); return nil }
+
+func runRemix(args []string) error {
+	fs := flag.NewFlagSet("remix", flag.ExitOnError)
+	cf := &clientFlags{}
+	cf.register(fs)
+	var refs refFlags
+	fs.Var(&refs, "ref", "path to a reference image; repeat for multiple (required)")
+	prompt := fs.String("prompt", "", "text prompt describing the remix (required)")
+	ratio := fs.String("ratio", "", "aspect ratio override")
+	output := fs.String("o", "output.png", "output file path")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if len(refs) == 0 || *prompt == "" {
+		return fmt.Errorf("at least one -ref and -prompt are required")
+	}
+
+	images := make([]string, len(refs))
+	for i, path := range refs {
+		img, err := reve.NewImageFromFile(path)
+		if err != nil {
+			return fmt.Errorf("read reference image %s: %w", path, err)
+		}
+		images[i] = img.Base64()
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+
+	params := &reve.RemixParams{
+		Prompt:          *prompt,
+		ReferenceImages: images,
+		AspectRatio:     reve.AspectRatio(*ratio),
+	}
+	printLintWarnings(params.Lint())
+
+	result, err := client.Images.Remix(context.Background(), params)
+	if err != nil {
+		return err
+	}
+	if err := result.SaveTo(*output); err != nil {
+		return err
+	}
+	fmt.Printf("saved %s (credits used: %d)\n", *output, result.CreditsUsed)
+	return nil
+}
+
+// printLintWarnings prints each warning to stderr, prefixed so it reads
+// distinctly from a fatal error, without stopping the command: Lint
+// warnings are advisory, not validation failures.
+func printLintWarnings(warnings []reve.Warning) {
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "reve: warning: %s\n", w)
+	}
+}
+
+func runBatch(args []string) error {
+	fs := flag.NewFlagSet("batch", flag.ExitOnError)
+	cf := &clientFlags{}
+	cf.register(fs)
+	file := fs.String("f", "", "path to a file with one prompt per line (required)")
+	outDir := fs.String("o", "out", "directory to write generated images to")
+	concurrency := fs.Int("concurrency", 4, "number of concurrent requests")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("-f is required")
+	}
+
+	prompts, err := readPrompts(*file)
+	if err != nil {
+		return err
+	}
+	if len(prompts) == 0 {
+		return fmt.Errorf("%s contains no prompts", *file)
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		return err
+	}
+
+	params := make([]*reve.CreateParams, len(prompts))
+	for i, p := range prompts {
+		params[i] = &reve.CreateParams{Prompt: p}
+	}
+
+	results := client.Images.BatchCreate(context.Background(), params, &reve.BatchConfig{
+		Concurrency: *concurrency,
+	})
+
+	failures := 0
+	for _, r := range results {
+		if r.Error != nil {
+			fmt.Fprintf(os.Stderr, "prompt %d failed: %v\n", r.Index, r.Error)
+			failures++
+			continue
+		}
+		path := filepath.Join(*outDir, strconv.Itoa(r.Index)+".png")
+		if err := r.Result.SaveTo(path); err != nil {
+			fmt.Fprintf(os.Stderr, "prompt %d: save failed: %v\n", r.Index, err)
+			failures++
+			continue
+		}
+		fmt.Printf("saved %s\n", path)
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d prompts failed", failures, len(results))
+	}
+	return nil
+}
+
+// readPrompts reads one prompt per non-empty, non-comment line of path.
+func readPrompts(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var prompts []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		prompts = append(prompts, line)
+	}
+	return prompts, scanner.Err()
+}
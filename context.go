@@ -0,0 +1,82 @@
+package reve
+
+import (
+	"context"
+	"time"
+
+	"github.com/shamspias/reve-go/internal/transport"
+)
+
+// ContextOption overrides part of a single request from context
+// instead of an explicit image.RequestOption argument, for code paths
+// where threading options through every Create/Edit/Remix call isn't
+// practical -- e.g. middleware several layers above the call that
+// needs to force a shorter timeout or tag a breadcrumb. Applied after
+// any image.RequestOption values already set on the same call.
+type ContextOption func(*transport.Request)
+
+// ContextWithOptions returns a context carrying opts, which every
+// Create, Edit, and Remix call made with it applies to its request,
+// however deep in the call stack it happens.
+//
+// Example:
+//
+//	ctx = reve.ContextWithOptions(ctx, reve.WithContextTimeout(5*time.Second))
+//	result, err := client.Images.Create(ctx, params) // deep inside a handler
+func ContextWithOptions(ctx context.Context, opts ...ContextOption) context.Context {
+	overrides := make([]transport.Override, len(opts))
+	for i, opt := range opts {
+		overrides[i] = transport.Override(opt)
+	}
+	return transport.ContextWithOverrides(ctx, overrides...)
+}
+
+// WithContextTimeout overrides the request timeout.
+func WithContextTimeout(d time.Duration) ContextOption {
+	return func(r *transport.Request) {
+		r.Timeout = d
+	}
+}
+
+// WithContextBreadcrumb overrides the request's tracking breadcrumb.
+func WithContextBreadcrumb(breadcrumb string) ContextOption {
+	return func(r *transport.Request) {
+		r.Breadcrumb = breadcrumb
+	}
+}
+
+// WithContextHeader sets an additional header on the request.
+func WithContextHeader(key, value string) ContextOption {
+	return func(r *transport.Request) {
+		if r.Headers == nil {
+			r.Headers = make(map[string]string)
+		}
+		r.Headers[key] = value
+	}
+}
+
+// WithBreadcrumb tags every request made with ctx with breadcrumb,
+// for code paths that don't have direct access to CreateParams,
+// EditParams, or RemixParams to set Breadcrumb themselves.
+//
+// Example:
+//
+//	ctx = reve.WithBreadcrumb(ctx, requestID)
+//	result, err := client.Images.Create(ctx, params) // deep inside a handler
+func WithBreadcrumb(ctx context.Context, breadcrumb string) context.Context {
+	return ContextWithOptions(ctx, WithContextBreadcrumb(breadcrumb))
+}
+
+// WithTraceparent tags every request made with ctx with a W3C Trace
+// Context traceparent header, and -- when the call doesn't set its own
+// Breadcrumb -- uses the traceparent's trace ID as the breadcrumb, so
+// Reve's server-side logs can be correlated with the caller's own
+// traces without per-call wiring.
+//
+// Example:
+//
+//	ctx = reve.WithTraceparent(ctx, r.Header.Get("traceparent"))
+//	result, err := client.Images.Create(ctx, params)
+func WithTraceparent(ctx context.Context, traceparent string) context.Context {
+	return transport.ContextWithTraceparent(ctx, traceparent)
+}
@@ -0,0 +1,74 @@
+// Package credentials stores and retrieves API keys using the host
+// OS's credential manager (macOS Keychain, Windows Credential Manager),
+// so a developer or desktop tool doesn't have to leave a long-lived key
+// sitting in plaintext in a shell profile or config file.
+package credentials
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shamspias/reve-go/internal/transport"
+)
+
+// ErrUnsupportedPlatform is returned by NewKeychainStore on platforms
+// this package has no native credential manager integration for.
+var ErrUnsupportedPlatform = errors.New("credentials: no keychain support for this platform")
+
+// ErrNotFound is returned by Store.Get when no credential exists for
+// the given service and account.
+var ErrNotFound = errors.New("credentials: not found")
+
+// Store reads and writes a single secret from the OS credential
+// manager, keyed by a service name (e.g. "reve-go") and an account
+// name (e.g. an email or key label).
+type Store interface {
+	// Get retrieves the secret stored for service and account, or
+	// ErrNotFound if none exists.
+	Get(service, account string) (string, error)
+
+	// Set stores secret for service and account, overwriting any
+	// existing value.
+	Set(service, account, secret string) error
+
+	// Delete removes the secret stored for service and account. It is
+	// not an error if none exists.
+	Delete(service, account string) error
+}
+
+// NewKeychainStore returns a Store backed by the host OS's native
+// credential manager: the macOS Keychain or Windows Credential Manager.
+// It returns ErrUnsupportedPlatform on other platforms.
+func NewKeychainStore() (Store, error) {
+	return newKeychainStore()
+}
+
+// KeyProvider returns a transport.KeyProvider that fetches the API key
+// stored under service and account in the host OS credential manager
+// each time the client needs one, so the key can be rotated externally
+// (e.g. with the OS's keychain UI) without recreating the client.
+//
+// Example:
+//
+//	provider, err := credentials.KeyProvider("reve-go", "default")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	client := reve.NewClient("", reve.WithKeyProvider(provider))
+func KeyProvider(service, account string) (transport.KeyProvider, error) {
+	store, err := NewKeychainStore()
+	if err != nil {
+		return nil, err
+	}
+	return keyProviderFromStore(store, service, account)
+}
+
+// keyProviderFromStore builds the transport.KeyProvider closure around
+// an already-resolved Store, split out from KeyProvider so it can be
+// exercised with a fake Store in tests that don't have a real OS
+// credential manager available.
+func keyProviderFromStore(store Store, service, account string) (transport.KeyProvider, error) {
+	return func(_ context.Context) (string, error) {
+		return store.Get(service, account)
+	}, nil
+}
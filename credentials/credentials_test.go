@@ -0,0 +1,92 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+)
+
+// fakeStore is an in-memory Store used to test the platform-independent
+// wiring in this file without touching an OS-native credential manager.
+type fakeStore struct {
+	secrets map[string]string
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{secrets: make(map[string]string)}
+}
+
+func key(service, account string) string {
+	return service + "\x00" + account
+}
+
+func (f *fakeStore) Get(service, account string) (string, error) {
+	secret, ok := f.secrets[key(service, account)]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return secret, nil
+}
+
+func (f *fakeStore) Set(service, account, secret string) error {
+	f.secrets[key(service, account)] = secret
+	return nil
+}
+
+func (f *fakeStore) Delete(service, account string) error {
+	delete(f.secrets, key(service, account))
+	return nil
+}
+
+func TestKeyProviderFetchesFromStoreOnEachCall(t *testing.T) {
+	store := newFakeStore()
+	if err := store.Set("reve-go", "default", "sk-first"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	provider, err := keyProviderFromStore(store, "reve-go", "default")
+	if err != nil {
+		t.Fatalf("keyProviderFromStore: %v", err)
+	}
+
+	got, err := provider(context.Background())
+	if err != nil {
+		t.Fatalf("provider() error = %v", err)
+	}
+	if got != "sk-first" {
+		t.Errorf("provider() = %q, want sk-first", got)
+	}
+
+	// A provider re-reads the store each call, so external rotation
+	// (e.g. via the OS's keychain UI) takes effect without recreating
+	// the client.
+	if err := store.Set("reve-go", "default", "sk-rotated"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	got, err = provider(context.Background())
+	if err != nil {
+		t.Fatalf("provider() error = %v", err)
+	}
+	if got != "sk-rotated" {
+		t.Errorf("provider() after rotation = %q, want sk-rotated", got)
+	}
+}
+
+func TestKeyProviderPropagatesNotFound(t *testing.T) {
+	store := newFakeStore()
+	provider, err := keyProviderFromStore(store, "reve-go", "missing")
+	if err != nil {
+		t.Fatalf("keyProviderFromStore: %v", err)
+	}
+	if _, err := provider(context.Background()); err != ErrNotFound {
+		t.Errorf("provider() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestNewKeychainStoreOnUnsupportedPlatformReturnsErrUnsupportedPlatform(t *testing.T) {
+	// This process runs on linux, which has no native keychain
+	// integration in this package (see keychain_other.go).
+	_, err := NewKeychainStore()
+	if err != ErrUnsupportedPlatform {
+		t.Errorf("NewKeychainStore() error = %v, want ErrUnsupportedPlatform", err)
+	}
+}
@@ -0,0 +1,54 @@
+//go:build darwin
+
+package credentials
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// darwinKeychainStore shells out to the `security` command-line tool
+// bundled with macOS, rather than using cgo to call the Keychain
+// Services C API directly, keeping this module cgo-free and
+// dependency-free.
+type darwinKeychainStore struct{}
+
+func newKeychainStore() (Store, error) {
+	return darwinKeychainStore{}, nil
+}
+
+func (darwinKeychainStore) Get(service, account string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password",
+		"-s", service, "-a", account, "-w").Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 44 {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+func (darwinKeychainStore) Set(service, account, secret string) error {
+	// -U updates the item in place if it already exists, instead of
+	// failing with "already exists".
+	cmd := exec.Command("security", "add-generic-password",
+		"-s", service, "-a", account, "-w", secret, "-U")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("security add-generic-password: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (darwinKeychainStore) Delete(service, account string) error {
+	err := exec.Command("security", "delete-generic-password",
+		"-s", service, "-a", account).Run()
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 44 {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,7 @@
+//go:build !darwin && !windows
+
+package credentials
+
+func newKeychainStore() (Store, error) {
+	return nil, ErrUnsupportedPlatform
+}
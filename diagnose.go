@@ -0,0 +1,197 @@
+package reve
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DiagnoseOptions configures Diagnose.
+type DiagnoseOptions struct {
+	// APIKey is checked against the API during the auth validity check.
+	// Optional; the check is skipped when empty.
+	APIKey string
+
+	// BaseURL is the endpoint to check. Default: DefaultBaseURL.
+	BaseURL string
+
+	// Timeout bounds each individual check. Default: 10s.
+	Timeout time.Duration
+}
+
+// DiagnoseCheck is the outcome of one connectivity check.
+type DiagnoseCheck struct {
+	Name   string
+	OK     bool
+	Detail string
+}
+
+// DiagnoseReport is the structured result of Diagnose.
+type DiagnoseReport struct {
+	Checks []DiagnoseCheck
+}
+
+// Failed reports whether any check in the report failed.
+func (r *DiagnoseReport) Failed() bool {
+	for _, c := range r.Checks {
+		if !c.OK {
+			return true
+		}
+	}
+	return false
+}
+
+// Diagnose runs DNS resolution, proxy reachability, a TLS handshake,
+// clock skew, and (when an APIKey is given) auth validity checks against
+// the API endpoint in sequence, returning a structured report. The large
+// majority of "the SDK doesn't work" reports turn out to be
+// environmental, and this triages them without anyone reading raw logs.
+//
+// Example:
+//
+//	report := reve.Diagnose(ctx, reve.DiagnoseOptions{APIKey: os.Getenv("REVE_API_KEY")})
+//	if report.Failed() {
+//		for _, c := range report.Checks {
+//			fmt.Printf("%s: ok=%v %s\n", c.Name, c.OK, c.Detail)
+//		}
+//	}
+func Diagnose(ctx context.Context, opts DiagnoseOptions) *DiagnoseReport {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	report := &DiagnoseReport{}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		report.Checks = append(report.Checks, DiagnoseCheck{
+			Name: "base_url", OK: false, Detail: err.Error(),
+		})
+		return report
+	}
+
+	report.Checks = append(report.Checks,
+		diagnoseDNS(ctx, u.Hostname(), opts.Timeout),
+		diagnoseProxy(u),
+		diagnoseTLS(u, opts.Timeout),
+		diagnoseClockSkew(ctx, u, opts.Timeout),
+	)
+	if opts.APIKey != "" {
+		report.Checks = append(report.Checks, diagnoseAuth(ctx, u, opts.APIKey, opts.Timeout))
+	}
+	return report
+}
+
+func diagnoseDNS(ctx context.Context, host string, timeout time.Duration) DiagnoseCheck {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return DiagnoseCheck{Name: "dns", OK: false, Detail: err.Error()}
+	}
+	return DiagnoseCheck{Name: "dns", OK: true, Detail: fmt.Sprintf("resolved to %v", addrs)}
+}
+
+func diagnoseProxy(u *url.URL) DiagnoseCheck {
+	proxyURL, err := http.ProxyFromEnvironment(&http.Request{URL: u})
+	if err != nil {
+		return DiagnoseCheck{Name: "proxy", OK: false, Detail: err.Error()}
+	}
+	if proxyURL == nil {
+		return DiagnoseCheck{Name: "proxy", OK: true, Detail: "no proxy configured"}
+	}
+
+	conn, err := net.DialTimeout("tcp", proxyURL.Host, 5*time.Second)
+	if err != nil {
+		return DiagnoseCheck{Name: "proxy", OK: false, Detail: fmt.Sprintf("unreachable: %v", err)}
+	}
+	conn.Close()
+	return DiagnoseCheck{Name: "proxy", OK: true, Detail: fmt.Sprintf("reachable via %s", proxyURL.Host)}
+}
+
+func diagnoseTLS(u *url.URL, timeout time.Duration) DiagnoseCheck {
+	if u.Scheme != "https" {
+		return DiagnoseCheck{Name: "tls", OK: true, Detail: "skipped: base URL is not https"}
+	}
+
+	addr := u.Hostname() + ":" + portOrDefault(u, "443")
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", addr, nil)
+	if err != nil {
+		return DiagnoseCheck{Name: "tls", OK: false, Detail: err.Error()}
+	}
+	defer conn.Close()
+
+	cert := conn.ConnectionState().PeerCertificates[0]
+	return DiagnoseCheck{
+		Name: "tls", OK: true,
+		Detail: fmt.Sprintf("handshake ok, cert valid until %s", cert.NotAfter.Format(time.RFC3339)),
+	}
+}
+
+func diagnoseClockSkew(ctx context.Context, u *url.URL, timeout time.Duration) DiagnoseCheck {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.String(), nil)
+	if err != nil {
+		return DiagnoseCheck{Name: "clock_skew", OK: false, Detail: err.Error()}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return DiagnoseCheck{Name: "clock_skew", OK: false, Detail: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return DiagnoseCheck{Name: "clock_skew", OK: true, Detail: "server did not send a Date header"}
+	}
+
+	skew := time.Since(serverTime)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > 5*time.Minute {
+		return DiagnoseCheck{Name: "clock_skew", OK: false, Detail: fmt.Sprintf("local clock is off by %s", skew)}
+	}
+	return DiagnoseCheck{Name: "clock_skew", OK: true, Detail: fmt.Sprintf("within %s", skew)}
+}
+
+func diagnoseAuth(ctx context.Context, u *url.URL, apiKey string, timeout time.Duration) DiagnoseCheck {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.String(), nil)
+	if err != nil {
+		return DiagnoseCheck{Name: "auth", OK: false, Detail: err.Error()}
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return DiagnoseCheck{Name: "auth", OK: false, Detail: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return DiagnoseCheck{Name: "auth", OK: false, Detail: "server rejected the API key (401)"}
+	}
+	return DiagnoseCheck{Name: "auth", OK: true, Detail: fmt.Sprintf("server reachable, status %d", resp.StatusCode)}
+}
+
+func portOrDefault(u *url.URL, def string) string {
+	if p := u.Port(); p != "" {
+		return p
+	}
+	return def
+}
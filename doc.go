@@ -56,6 +56,23 @@
 //	)
 //
 // For more examples, see the examples directory.
+//
+// # Stability
+//
+// Everything importable without a build tag is stable: it follows
+// semantic versioning, and a breaking change bumps the major version.
+//
+// A new subsystem large enough to need its own design-in-the-open
+// period (a queue, a webhook transport, an MCP integration) instead
+// starts behind the "experimental" build tag:
+//
+//	go build -tags experimental ./...
+//
+// Its package doc says so explicitly ("Package <name> is experimental:
+// ..."), and its exported API can change or disappear in a minor or
+// patch release without that counting as a breaking change. Once a
+// subsystem's shape has settled, its build tag is dropped in a minor
+// release and it joins the stable surface.
 package reve
 
 // Version is the SDK version.
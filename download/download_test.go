@@ -0,0 +1,94 @@
+package download
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDownloadAllFetchesFile(t *testing.T) {
+	const body = "hello world"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "out.bin")
+	m := NewManager(1)
+
+	results := m.DownloadAll(context.Background(), []Job{{URL: srv.URL, Dest: dest}})
+	if len(results) != 1 {
+		t.Fatalf("len(results) = %d, want 1", len(results))
+	}
+	if results[0].Err != nil {
+		t.Fatalf("Err = %v, want nil", results[0].Err)
+	}
+	if results[0].Bytes != int64(len(body)) {
+		t.Errorf("Bytes = %d, want %d", results[0].Bytes, len(body))
+	}
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(got) != body {
+		t.Errorf("file content = %q, want %q", got, body)
+	}
+}
+
+func TestDownloadChecksumMismatchRemovesCorruptPartFileBeforeRetry(t *testing.T) {
+	const body = "hello world"
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Range") != "" {
+			t.Errorf("request %d: Range = %q, want no Range header (corrupt .part should force a fresh download)", requests, r.Header.Get("Range"))
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "out.bin")
+	m := NewManager(1)
+	m.MaxAttempts = 2
+
+	results := m.DownloadAll(context.Background(), []Job{{URL: srv.URL, Dest: dest, SHA256: "deadbeef"}})
+	if len(results) != 1 {
+		t.Fatalf("len(results) = %d, want 1", len(results))
+	}
+	var mismatch *ErrChecksumMismatch
+	if results[0].Err == nil {
+		t.Fatal("Err = nil, want ErrChecksumMismatch")
+	}
+	if _, ok := results[0].Err.(*ErrChecksumMismatch); !ok {
+		_ = mismatch
+		t.Fatalf("Err = %T, want *ErrChecksumMismatch", results[0].Err)
+	}
+	if requests != m.MaxAttempts {
+		t.Errorf("requests = %d, want %d", requests, m.MaxAttempts)
+	}
+	if _, err := os.Stat(dest + ".part"); !os.IsNotExist(err) {
+		t.Errorf(".part file still exists after final failed attempt, want removed")
+	}
+}
+
+func TestVerifyChecksumMatches(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "f.bin")
+	if err := os.WriteFile(path, []byte("hello world"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	// sha256("hello world")
+	const want = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+	if err := verifyChecksum(path, "http://example.com", want); err != nil {
+		t.Errorf("verifyChecksum() = %v, want nil", err)
+	}
+	if err := verifyChecksum(path, "http://example.com", "wrong"); err == nil {
+		t.Error("verifyChecksum() = nil, want ErrChecksumMismatch")
+	}
+}
@@ -0,0 +1,53 @@
+//go:build experimental
+
+// Example: Webapp
+//
+// This example wires a *reve.Client's caching, quota, and observability
+// subsystems into a small HTTP service backed by a fixed worker pool
+// (package server, in this directory), so it doubles as an integration
+// test bed and as a template to fork for a real service.
+//
+// Run with:
+//
+//	REVE_API_KEY=your-key go run -tags experimental .
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	reve "github.com/shamspias/reve-go"
+	"github.com/shamspias/reve-go/cache"
+	"github.com/shamspias/reve-go/examples/webapp/server"
+	"github.com/shamspias/reve-go/otellog"
+	"github.com/shamspias/reve-go/quota"
+)
+
+func main() {
+	apiKey := os.Getenv("REVE_API_KEY")
+	if apiKey == "" {
+		log.Fatal("REVE_API_KEY environment variable is required")
+	}
+
+	budget := quota.NewManager(1000, []quota.Partition{
+		{Name: "interactive", SharePercent: 80},
+		{Name: "background", SharePercent: 20},
+	})
+
+	client := reve.NewClient(apiKey,
+		reve.WithCache(cache.NewMemoryLRU(256)),
+		reve.WithQuotaManager(budget),
+		reve.WithEventSink(otellog.NewSink(os.Stdout)),
+		reve.WithTimeout(60*time.Second),
+	)
+
+	srv := server.NewServer(client.Images, 4)
+	defer srv.Close()
+
+	addr := ":8080"
+	fmt.Printf("listening on %s (POST /generate, GET /healthz, GET /debug/vars)\n", addr)
+	log.Fatal(http.ListenAndServe(addr, srv))
+}
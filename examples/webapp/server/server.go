@@ -0,0 +1,134 @@
+//go:build experimental
+
+// Package server is the reusable half of examples/webapp: an HTTP
+// frontend over a fixed-size queue.Queue worker pool, wired so the
+// request body decides nothing the SDK's own subsystems -- caching,
+// quota, observability -- don't already handle. main.go just wires a
+// *reve.Client's subsystems and calls NewServer; everything else lives
+// here so it can be imported and tested like any other package, not
+// just read as a sample.
+//
+// Building examples/webapp requires the "experimental" tag, since it
+// depends on the experimental queue package:
+//
+//	go build -tags experimental ./examples/webapp/...
+package server
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+
+	"github.com/shamspias/reve-go/image"
+	"github.com/shamspias/reve-go/queue"
+	"github.com/shamspias/reve-go/types"
+)
+
+// Server is an HTTP frontend for a fixed-size Create worker pool. The
+// zero value is not usable; construct one with NewServer.
+type Server struct {
+	jobs *queue.Queue
+	mux  *http.ServeMux
+
+	requestsTotal    *expvar.Int
+	requestsFailed   *expvar.Int
+	requestsInFlight *expvar.Int
+}
+
+// NewServer starts a Server backed by a queue.Queue of workers workers,
+// all running Create calls through images. Routes:
+//
+//	POST /generate  decode generateRequest, enqueue, wait for the result
+//	GET  /healthz   always 200 once the server is constructed
+//	GET  /debug/vars expvar counters: webapp_requests_total, webapp_requests_failed, webapp_requests_in_flight
+func NewServer(images *image.Service, workers int) *Server {
+	s := &Server{
+		jobs:             queue.New(images, workers),
+		mux:              http.NewServeMux(),
+		requestsTotal:    new(expvar.Int),
+		requestsFailed:   new(expvar.Int),
+		requestsInFlight: new(expvar.Int),
+	}
+
+	s.mux.HandleFunc("/generate", s.handleGenerate)
+	s.mux.HandleFunc("/healthz", s.handleHealthz)
+	s.mux.Handle("/debug/vars", expvar.Handler())
+
+	expvar.Publish("webapp_requests_total", s.requestsTotal)
+	expvar.Publish("webapp_requests_failed", s.requestsFailed)
+	expvar.Publish("webapp_requests_in_flight", s.requestsInFlight)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Close stops accepting new generate requests and waits for in-flight
+// ones to finish.
+func (s *Server) Close() {
+	s.jobs.Close()
+}
+
+// generateRequest is the POST /generate request body.
+type generateRequest struct {
+	Prompt      string `json:"prompt"`
+	AspectRatio string `json:"aspect_ratio,omitempty"`
+}
+
+// generateResponse is the POST /generate response body.
+type generateResponse struct {
+	RequestID    string `json:"request_id"`
+	CreditsUsed  int    `json:"credits_used"`
+	AttemptCount int    `json:"attempt_count"`
+	LatencyMS    int64  `json:"latency_ms"`
+}
+
+func (s *Server) handleGenerate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req generateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	s.requestsTotal.Add(1)
+	s.requestsInFlight.Add(1)
+	defer s.requestsInFlight.Add(-1)
+
+	job, err := s.jobs.Enqueue(r.Context(), &image.CreateParams{
+		Prompt:      req.Prompt,
+		AspectRatio: types.AspectRatio(req.AspectRatio),
+	})
+	if err != nil {
+		s.requestsFailed.Add(1)
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	result, err := job.Wait(r.Context())
+	if err != nil {
+		s.requestsFailed.Add(1)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(generateResponse{
+		RequestID:    result.RequestID,
+		CreditsUsed:  result.CreditsUsed,
+		AttemptCount: result.AttemptCount,
+		LatencyMS:    result.Latency.Milliseconds(),
+	})
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
@@ -0,0 +1,24 @@
+//go:build http3
+
+package reve
+
+import "github.com/shamspias/reve-go/internal/transport"
+
+// WithHTTP3 switches the client to HTTP/3 over QUIC, worth trying on
+// lossy networks where TCP head-of-line blocking materially slows down
+// large-image downloads. Overrides any transport set by an earlier
+// option.
+//
+// Experimental: only available when built with -tags http3, since it
+// pulls in quic-go, a dependency this module otherwise avoids. Run
+//
+//	go build -tags http3 ./...
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithHTTP3())
+func WithHTTP3() Option {
+	return func(c *Config) {
+		c.Transport = transport.CreateHTTP3Transport()
+	}
+}
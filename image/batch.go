@@ -16,6 +16,16 @@ type BatchConfig struct {
 	// StopOnError stops on first error.
 	// Default: false
 	StopOnError bool
+
+	// Progress, if set, receives OnItemStart/OnItemDone callbacks as the
+	// batch runs, for rendering a progress bar or ETA. OnBytes is not
+	// called by batch methods; see image.WithProgress for raw downloads.
+	Progress Progress
+
+	// Checkpoint, if set, is consulted to skip indices already completed
+	// by a prior, interrupted run of the same params slice, and updated
+	// as each new index succeeds. See LoadCheckpoint.
+	Checkpoint *Checkpoint
 }
 
 // DefaultBatchConfig returns default configuration.
@@ -36,6 +46,11 @@ type BatchResult struct {
 
 	// Error is the error if failed.
 	Error error
+
+	// Skipped reports whether this index was skipped because
+	// BatchConfig.Checkpoint already recorded it as done from a prior
+	// run. Result and Error are both nil when Skipped is true.
+	Skipped bool
 }
 
 // BatchCreate executes multiple create requests concurrently.
@@ -84,6 +99,11 @@ func (s *Service) BatchCreate(ctx context.Context, params []*CreateParams, confi
 		}
 		stopMu.Unlock()
 
+		if config.Checkpoint != nil && config.Checkpoint.Done(i) {
+			results[i] = BatchResult{Index: i, Skipped: true}
+			continue
+		}
+
 		wg.Add(1)
 		go func(idx int, req *CreateParams) {
 			defer wg.Done()
@@ -96,8 +116,17 @@ func (s *Service) BatchCreate(ctx context.Context, params []*CreateParams, confi
 				return
 			}
 
+			if config.Progress != nil {
+				config.Progress.OnItemStart(idx)
+			}
 			result, err := s.Create(ctx, req)
 			results[idx] = BatchResult{Index: idx, Result: result, Error: err}
+			if config.Progress != nil {
+				config.Progress.OnItemDone(idx, err)
+			}
+			if err == nil && config.Checkpoint != nil {
+				config.Checkpoint.mark(idx)
+			}
 
 			if err != nil && config.StopOnError {
 				stopMu.Lock()
@@ -111,6 +140,24 @@ func (s *Service) BatchCreate(ctx context.Context, params []*CreateParams, confi
 	return results
 }
 
+// Variations builds n copies of base with Seed set to base.Seed, base.Seed+1,
+// ..., base.Seed+n-1, for BatchCreate calls that explore systematic variations
+// of an otherwise fixed prompt. base is not mutated.
+//
+// Example:
+//
+//	base := &image.CreateParams{Prompt: "a ceramic mug", Seed: 1000}
+//	results := client.Images.BatchCreate(ctx, image.Variations(base, 4), nil)
+func Variations(base *CreateParams, n int) []*CreateParams {
+	out := make([]*CreateParams, n)
+	for i := 0; i < n; i++ {
+		p := *base
+		p.Seed = base.Seed + int64(i)
+		out[i] = &p
+	}
+	return out
+}
+
 // BatchEdit executes multiple edit requests concurrently.
 //
 // Example:
@@ -148,6 +195,11 @@ func (s *Service) BatchEdit(ctx context.Context, params []*EditParams, config *B
 		}
 		stopMu.Unlock()
 
+		if config.Checkpoint != nil && config.Checkpoint.Done(i) {
+			results[i] = BatchResult{Index: i, Skipped: true}
+			continue
+		}
+
 		wg.Add(1)
 		go func(idx int, req *EditParams) {
 			defer wg.Done()
@@ -160,8 +212,17 @@ func (s *Service) BatchEdit(ctx context.Context, params []*EditParams, config *B
 				return
 			}
 
+			if config.Progress != nil {
+				config.Progress.OnItemStart(idx)
+			}
 			result, err := s.Edit(ctx, req)
 			results[idx] = BatchResult{Index: idx, Result: result, Error: err}
+			if config.Progress != nil {
+				config.Progress.OnItemDone(idx, err)
+			}
+			if err == nil && config.Checkpoint != nil {
+				config.Checkpoint.mark(idx)
+			}
 
 			if err != nil && config.StopOnError {
 				stopMu.Lock()
@@ -201,6 +262,11 @@ func (s *Service) BatchRemix(ctx context.Context, params []*RemixParams, config
 		}
 		stopMu.Unlock()
 
+		if config.Checkpoint != nil && config.Checkpoint.Done(i) {
+			results[i] = BatchResult{Index: i, Skipped: true}
+			continue
+		}
+
 		wg.Add(1)
 		go func(idx int, req *RemixParams) {
 			defer wg.Done()
@@ -213,8 +279,17 @@ func (s *Service) BatchRemix(ctx context.Context, params []*RemixParams, config
 				return
 			}
 
+			if config.Progress != nil {
+				config.Progress.OnItemStart(idx)
+			}
 			result, err := s.Remix(ctx, req)
 			results[idx] = BatchResult{Index: idx, Result: result, Error: err}
+			if config.Progress != nil {
+				config.Progress.OnItemDone(idx, err)
+			}
+			if err == nil && config.Checkpoint != nil {
+				config.Checkpoint.mark(idx)
+			}
 
 			if err != nil && config.StopOnError {
 				stopMu.Lock()
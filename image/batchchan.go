@@ -0,0 +1,82 @@
+package image
+
+import (
+	"context"
+	"sync"
+)
+
+// CreateBatchChan is the channel-based counterpart to BatchCreate and
+// GenerateSeq, for producer/consumer pipelines where prompts arrive
+// continuously (e.g. read off a message queue) instead of as a fixed
+// slice. It starts up to config.Concurrency workers pulling from in and
+// returns immediately with a channel of results, each tagged with its
+// consumption order in Index.
+//
+// The returned channel is closed once in is closed and every in-flight
+// request has completed, or once ctx is cancelled. config.StopOnError
+// is ignored; a streaming source has no "remaining requests" to skip.
+//
+// Example:
+//
+//	in := make(chan *image.CreateParams)
+//	go consumeQueue(queue, in) // closes in when the queue is drained
+//
+//	for r := range client.Images.CreateBatchChan(ctx, in, nil) {
+//		if r.Error != nil {
+//			log.Printf("request %d failed: %v", r.Index, r.Error)
+//			continue
+//		}
+//		r.Result.SaveTo(fmt.Sprintf("out_%d.png", r.Index))
+//	}
+func (s *Service) CreateBatchChan(ctx context.Context, in <-chan *CreateParams, config *BatchConfig) <-chan BatchResult {
+	if config == nil {
+		config = DefaultBatchConfig()
+	}
+	concurrency := config.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	out := make(chan BatchResult)
+	sem := make(chan struct{}, concurrency)
+
+	go func() {
+		defer close(out)
+
+		var wg sync.WaitGroup
+		index := 0
+
+	feed:
+		for {
+			select {
+			case params, ok := <-in:
+				if !ok {
+					break feed
+				}
+				idx := index
+				index++
+
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					out <- BatchResult{Index: idx, Error: ctx.Err()}
+					break feed
+				}
+
+				wg.Add(1)
+				go func(idx int, params *CreateParams) {
+					defer wg.Done()
+					defer func() { <-sem }()
+					result, err := s.Create(ctx, params)
+					out <- BatchResult{Index: idx, Result: result, Error: err}
+				}(idx, params)
+			case <-ctx.Done():
+				break feed
+			}
+		}
+
+		wg.Wait()
+	}()
+
+	return out
+}
@@ -0,0 +1,57 @@
+package image
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/shamspias/reve-go/internal/transport"
+)
+
+// attachReferenceImage sets req.Files from referenceImage when the
+// transport has binary uploads enabled (see reve.WithBinaryUploads), so
+// Edit sends the reference image as a multipart file part instead of
+// leaving it base64-encoded in the JSON body.
+func (s *Service) attachReferenceImage(req *transport.Request, fieldName, referenceImage string) error {
+	if !s.transport.BinaryUploadsEnabled() || referenceImage == "" {
+		return nil
+	}
+	file, err := decodeMultipartFile(fieldName, referenceImage)
+	if err != nil {
+		return err
+	}
+	req.Files = []*transport.MultipartFile{file}
+	return nil
+}
+
+// attachReferenceImages sets req.Files from referenceImages when the
+// transport has binary uploads enabled, so Remix sends each reference
+// image as its own multipart file part instead of leaving them
+// base64-encoded in the JSON body.
+func (s *Service) attachReferenceImages(req *transport.Request, fieldName string, referenceImages []string) error {
+	if !s.transport.BinaryUploadsEnabled() || len(referenceImages) == 0 {
+		return nil
+	}
+	files := make([]*transport.MultipartFile, len(referenceImages))
+	for i, img := range referenceImages {
+		file, err := decodeMultipartFile(fmt.Sprintf("%s[%d]", fieldName, i), img)
+		if err != nil {
+			return err
+		}
+		files[i] = file
+	}
+	req.Files = files
+	return nil
+}
+
+func decodeMultipartFile(fieldName, base64Image string) (*transport.MultipartFile, error) {
+	data, err := base64.StdEncoding.DecodeString(base64Image)
+	if err != nil {
+		return nil, err
+	}
+	return &transport.MultipartFile{
+		FieldName: fieldName,
+		FileName:  fieldName + ".bin",
+		Reader:    bytes.NewReader(data),
+	}, nil
+}
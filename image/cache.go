@@ -0,0 +1,50 @@
+package image
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/shamspias/reve-go/types"
+)
+
+// Cache stores a *types.Result keyed by a canonical hash of the request
+// that produced it, so re-running a batch script doesn't re-spend
+// credits on an identical request. See reve.WithCache and the bundled
+// implementations in package cache.
+type Cache interface {
+	// Get returns the cached result for key, and whether it was found.
+	Get(ctx context.Context, key string) (*types.Result, bool, error)
+
+	// Set stores result under key.
+	Set(ctx context.Context, key string, result *types.Result) error
+}
+
+// CacheKey returns the canonical cache key for a request's prompt
+// (or instruction), seed, model version, and aspect ratio. Identical
+// inputs always hash to the same key, regardless of operation.
+func CacheKey(prompt string, seed int64, version types.ModelVersion, ratio types.AspectRatio) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d\x00%s\x00%s", prompt, seed, version, ratio)))
+	return hex.EncodeToString(sum[:])
+}
+
+// cached runs fn, consulting s.cache first and populating it with a
+// fresh result on a miss. With no cache configured, it just runs fn.
+func (s *Service) cached(ctx context.Context, key string, fn func() (*types.Result, error)) (*types.Result, error) {
+	if s.cache == nil {
+		return fn()
+	}
+
+	if result, ok, err := s.cache.Get(ctx, key); err == nil && ok {
+		return result, nil
+	}
+
+	result, err := fn()
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Set(ctx, key, result)
+	return result, nil
+}
@@ -0,0 +1,131 @@
+package image
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shamspias/reve-go/types"
+)
+
+// ModelCapabilities describes what a specific model version actually
+// supports, as reported by a CapabilityProvider.
+type ModelCapabilities struct {
+	// AspectRatios lists the ratios the version accepts. Empty means
+	// unrestricted (don't check).
+	AspectRatios []types.AspectRatio
+
+	// MaxNumImages caps NumImages for this version. Zero means
+	// unrestricted (don't check).
+	MaxNumImages int
+
+	// DisallowedPostprocessCombos lists sets of postprocessing types
+	// that can't be requested together for this version (e.g. upscale
+	// and remove_background in the same call). Order within a set
+	// doesn't matter. Empty means unrestricted (don't check).
+	DisallowedPostprocessCombos [][]types.ProcessType
+}
+
+// CapabilityProvider reports what a model version actually supports, so
+// Create, Edit, and Remix can reject a mismatched param before spending
+// a round trip on a request the API would refuse anyway, rather than
+// relying solely on the package's hardcoded defaults (which can drift
+// as new versions ship). Install with Service.SetCapabilityProvider
+// (see reve.WithCapabilityProvider).
+type CapabilityProvider interface {
+	// Capabilities returns version's capabilities, or ok=false if
+	// version isn't recognized, in which case the caller falls back to
+	// its hardcoded validation.
+	Capabilities(ctx context.Context, version types.ModelVersion) (caps ModelCapabilities, ok bool)
+}
+
+// ErrUnsupportedCapability is returned when a CapabilityProvider reports
+// that the requested model version doesn't support a param the caller
+// set.
+type ErrUnsupportedCapability struct {
+	Version types.ModelVersion
+	Param   string
+	Value   string
+}
+
+func (e *ErrUnsupportedCapability) Error() string {
+	return fmt.Sprintf("reve: model %s does not support %s=%s", e.Version, e.Param, e.Value)
+}
+
+// checkCapabilities validates ratio, numImages, and postprocess against
+// s.capabilities for version, if a CapabilityProvider is installed and
+// recognizes version. A zero numImages (single-image Create/Edit/Remix)
+// skips the MaxNumImages check.
+func (s *Service) checkCapabilities(ctx context.Context, version types.ModelVersion, ratio types.AspectRatio, numImages int, postprocess []types.Postprocess) error {
+	if s.capabilities == nil {
+		return nil
+	}
+	caps, ok := s.capabilities.Capabilities(ctx, version)
+	if !ok {
+		return nil
+	}
+
+	if ratio != "" && ratio != "auto" && len(caps.AspectRatios) > 0 {
+		supported := false
+		for _, r := range caps.AspectRatios {
+			if r == ratio {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return &ErrUnsupportedCapability{Version: version, Param: "aspect_ratio", Value: string(ratio)}
+		}
+	}
+
+	if numImages > 0 && caps.MaxNumImages > 0 && numImages > caps.MaxNumImages {
+		return &ErrUnsupportedCapability{Version: version, Param: "num_images", Value: fmt.Sprintf("%d", numImages)}
+	}
+
+	if combo := postprocessTypes(postprocess); len(combo) > 1 {
+		for _, disallowed := range caps.DisallowedPostprocessCombos {
+			if sameProcessSet(combo, disallowed) {
+				return &ErrUnsupportedCapability{Version: version, Param: "postprocessing", Value: fmt.Sprintf("%v", combo)}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Capabilities reports what version actually supports, per the
+// installed CapabilityProvider. Returns ok=false if no provider is
+// installed (see SetCapabilityProvider) or the provider doesn't
+// recognize version.
+func (s *Service) Capabilities(ctx context.Context, version types.ModelVersion) (ModelCapabilities, bool) {
+	if s.capabilities == nil {
+		return ModelCapabilities{}, false
+	}
+	return s.capabilities.Capabilities(ctx, version)
+}
+
+// postprocessTypes extracts the set of process types requested, for
+// comparison against a DisallowedPostprocessCombos entry.
+func postprocessTypes(postprocess []types.Postprocess) []types.ProcessType {
+	processes := make([]types.ProcessType, len(postprocess))
+	for i, pp := range postprocess {
+		processes[i] = pp.Process
+	}
+	return processes
+}
+
+// sameProcessSet reports whether a and b contain the same process
+// types, ignoring order and duplicates.
+func sameProcessSet(a, b []types.ProcessType) bool {
+	setA := make(map[types.ProcessType]bool, len(a))
+	for _, t := range a {
+		setA[t] = true
+	}
+	setB := make(map[types.ProcessType]bool, len(b))
+	for _, t := range b {
+		if !setA[t] {
+			return false
+		}
+		setB[t] = true
+	}
+	return len(setA) == len(setB)
+}
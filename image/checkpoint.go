@@ -0,0 +1,73 @@
+package image
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Checkpoint tracks which indices of a long BatchCreate, BatchEdit, or
+// BatchRemix run have already completed successfully, so a batch of
+// hundreds of prompts can resume after a crash or restart instead of
+// re-generating (and re-billing) work it already has. Safe for
+// concurrent use from the batch methods' worker goroutines.
+type Checkpoint struct {
+	path string
+
+	mu   sync.Mutex
+	done map[int]bool
+}
+
+// LoadCheckpoint reads path's completed indices, or starts a fresh,
+// empty Checkpoint if path doesn't exist yet. Pass the returned
+// Checkpoint to BatchConfig.Checkpoint to skip indices it already has
+// and persist newly completed ones back to path as the run progresses.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	c := &Checkpoint{path: path, done: make(map[int]bool)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var indices []int
+	if err := json.Unmarshal(data, &indices); err != nil {
+		return nil, fmt.Errorf("checkpoint: parse %s: %w", path, err)
+	}
+	for _, i := range indices {
+		c.done[i] = true
+	}
+	return c, nil
+}
+
+// Done reports whether index completed successfully in a prior run, so
+// a resumed batch can skip it rather than regenerating it.
+func (c *Checkpoint) Done(index int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done[index]
+}
+
+// mark records index as completed and rewrites the checkpoint file.
+func (c *Checkpoint) mark(index int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.done[index] = true
+	indices := make([]int, 0, len(c.done))
+	for i := range c.done {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+
+	data, err := json.Marshal(indices)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0o644)
+}
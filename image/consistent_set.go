@@ -0,0 +1,100 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/shamspias/reve-go/internal/validator"
+	"github.com/shamspias/reve-go/types"
+)
+
+// SetEntry is one aspect ratio's image within a consistency Set.
+type SetEntry struct {
+	AspectRatio types.AspectRatio
+	Result      *types.Result
+}
+
+// Set is a collection of the same scene rendered at different aspect
+// ratios, as returned by ConsistentSet.
+type Set []SetEntry
+
+// ByRatio returns the entry for ratio, or nil if the set doesn't include it.
+func (s Set) ByRatio(ratio types.AspectRatio) *SetEntry {
+	for i := range s {
+		if s[i].AspectRatio == ratio {
+			return &s[i]
+		}
+	}
+	return nil
+}
+
+// SaveAllTo saves every entry in s to dir, one file per aspect ratio,
+// named by substituting the ratio's digits for pattern's "*"
+// (e.g. pattern "banner_*.png" with ratio "16:9" writes "banner_16x9.png").
+func (s Set) SaveAllTo(dir, pattern string) error {
+	for _, entry := range s {
+		label := strings.ReplaceAll(entry.AspectRatio.String(), ":", "x")
+		name := strings.Replace(pattern, "*", label, 1)
+		if err := entry.Result.SaveTo(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("save %s: %w", entry.AspectRatio, err)
+		}
+	}
+	return nil
+}
+
+// ConsistentSet generates the same scene across multiple aspect ratios
+// for responsive campaigns (e.g. a 16:9 hero plus a 9:16 mobile crop)
+// that should read as the same image rather than independent rolls. The
+// first ratio is generated normally; its seed (or base.Seed, if set) is
+// then reused for every remaining ratio so the set stays visually
+// consistent. base is not mutated.
+//
+// Example:
+//
+//	set, err := image.ConsistentSet(ctx, client.Images,
+//		&image.CreateParams{Prompt: "product hero shot, studio lighting"},
+//		[]types.AspectRatio{types.Ratio16x9, types.Ratio9x16, types.Ratio1x1},
+//	)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = set.SaveAllTo("out", "hero_*.png")
+func ConsistentSet(ctx context.Context, svc *Service, base *CreateParams, ratios []types.AspectRatio) (Set, error) {
+	if base == nil {
+		return nil, validator.ErrEmptyPrompt
+	}
+	if len(ratios) == 0 {
+		return nil, nil
+	}
+
+	first := *base
+	first.AspectRatio = ratios[0]
+	result, err := svc.Create(ctx, &first)
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(Set, len(ratios))
+	set[0] = SetEntry{AspectRatio: ratios[0], Result: result}
+
+	seed := base.Seed
+	if seed == 0 {
+		seed = result.Seed
+	}
+
+	for i, ratio := range ratios[1:] {
+		p := *base
+		p.AspectRatio = ratio
+		p.Seed = seed
+
+		r, err := svc.Create(ctx, &p)
+		if err != nil {
+			return set[:i+1], err
+		}
+		set[i+1] = SetEntry{AspectRatio: ratio, Result: r}
+	}
+
+	return set, nil
+}
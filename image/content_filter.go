@@ -0,0 +1,53 @@
+package image
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ContentFilter screens a prompt locally before it is sent to the API,
+// returning a non-nil error to block the request. Used to catch
+// obviously violating prompts before spending credits and incurring a
+// CONTENT_POLICY_VIOLATION strike. See reve.WithContentFilter.
+type ContentFilter func(prompt string) error
+
+// ErrPromptBlocked is returned by NewKeywordScreener when a prompt
+// matches one of its patterns.
+type ErrPromptBlocked struct {
+	Pattern string
+}
+
+// Error implements the error interface.
+func (e *ErrPromptBlocked) Error() string {
+	return fmt.Sprintf("reve: prompt blocked by content filter (matched %q)", e.Pattern)
+}
+
+// NewKeywordScreener builds a basic ContentFilter that blocks any prompt
+// matching one of the given regular expressions, case-insensitively.
+// Patterns are compiled once up front; an invalid pattern returns an
+// error immediately so misconfiguration is caught at setup time rather
+// than on the first prompt.
+//
+// Example:
+//
+//	filter, err := image.NewKeywordScreener([]string{`\bweapon\b`, `\bgore\b`})
+//	client := reve.NewClient(apiKey, reve.WithContentFilter(filter))
+func NewKeywordScreener(patterns []string) (ContentFilter, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile("(?i)" + p)
+		if err != nil {
+			return nil, fmt.Errorf("reve: invalid content filter pattern %q: %w", p, err)
+		}
+		compiled = append(compiled, re)
+	}
+
+	return func(prompt string) error {
+		for _, re := range compiled {
+			if re.MatchString(prompt) {
+				return &ErrPromptBlocked{Pattern: re.String()}
+			}
+		}
+		return nil
+	}, nil
+}
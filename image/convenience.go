@@ -0,0 +1,52 @@
+package image
+
+import (
+	"context"
+
+	"github.com/shamspias/reve-go/types"
+)
+
+// CreateToFile generates an image with Create and saves it directly to
+// path, collapsing the common "generate, then save" sequence into one
+// call. If params.OutputFormat is unset, it is detected from path's
+// extension.
+//
+// Example:
+//
+//	err := client.Images.CreateToFile(ctx, &image.CreateParams{
+//		Prompt: "a ceramic mug, studio lighting",
+//	}, "mug.png")
+func (s *Service) CreateToFile(ctx context.Context, params *CreateParams, path string, opts ...RequestOption) error {
+	if params.OutputFormat == "" {
+		params.OutputFormat = types.DetectFormat(path)
+	}
+	result, err := s.Create(ctx, params, opts...)
+	if err != nil {
+		return err
+	}
+	return result.SaveTo(path)
+}
+
+// EditFile loads the reference image at inPath, applies instruction
+// with Edit, and saves the result to outPath, collapsing the common
+// "load, encode, edit, save" sequence into one call. The output format
+// sent via the Accept header is detected from outPath's extension.
+//
+// Example:
+//
+//	err := client.Images.EditFile(ctx, "photo.jpg", "convert to watercolor", "watercolor.png")
+func (s *Service) EditFile(ctx context.Context, inPath, instruction, outPath string, opts ...RequestOption) error {
+	img, err := types.NewImageFromFile(inPath)
+	if err != nil {
+		return err
+	}
+	result, err := s.Edit(ctx, &EditParams{
+		Instruction:    instruction,
+		ReferenceImage: img.Base64(),
+		OutputFormat:   types.DetectFormat(outPath),
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	return result.SaveTo(outPath)
+}
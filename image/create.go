@@ -2,7 +2,9 @@ package image
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/shamspias/reve-go/internal/transport"
@@ -10,12 +12,25 @@ import (
 	"github.com/shamspias/reve-go/types"
 )
 
+// errEmptyImage is reported in MultiResult.Errors for a response slot
+// that came back blank, e.g. a candidate blocked by content policy.
+var errEmptyImage = errors.New("empty image payload")
+
 // CreateParams contains parameters for creating an image.
 type CreateParams struct {
 	// Prompt is the text description (required).
 	// Maximum length: 2560 characters.
 	Prompt string `json:"prompt"`
 
+	// AutoStructure shortens a Prompt over validator.MaxPromptLength by
+	// heuristically splitting it into subject, style, and negative
+	// clauses and trimming the least essential ones first (negative,
+	// then style, then subject as a last resort), instead of failing
+	// Validate outright. The Reve API has no separate wire fields for
+	// these clauses -- Create always sends a single Prompt string -- so
+	// this only changes what ends up in that one field.
+	AutoStructure bool `json:"-"`
+
 	// AspectRatio is the desired aspect ratio.
 	// Default: 3:2
 	AspectRatio types.AspectRatio `json:"aspect_ratio,omitempty"`
@@ -31,8 +46,35 @@ type CreateParams struct {
 	// Default: 1
 	TestTimeScaling float64 `json:"test_time_scaling,omitempty"`
 
+	// Seed pins the generation's random seed for reproducibility. Two
+	// Create calls with the same Seed and otherwise identical params
+	// return the same image; omitted or zero lets the model choose one,
+	// which is then reported back on Result.Seed.
+	Seed int64 `json:"seed,omitempty"`
+
+	// NumImages requests multiple candidate images in a single round
+	// trip instead of N separate calls. Default: 1, max: 4. Create
+	// always returns just the first image; use CreateMulti to receive
+	// all of them.
+	NumImages int `json:"num_images,omitempty"`
+
+	// OutputFormat requests a specific binary format via the Accept header.
+	// When set to an image MIME type (e.g. types.FormatWebP), Create
+	// transparently fetches the image through the raw path and base64
+	// encodes it into Result.Image, so callers keep using the unified
+	// *types.Result return type regardless of wire format.
+	OutputFormat types.OutputFormat `json:"-"`
+
 	// Breadcrumb is an optional tracking ID.
 	Breadcrumb string `json:"-"`
+
+	// Draft makes this call a cheap, fast preview instead of a final
+	// image: it's routed to the fast model variant, TestTimeScaling is
+	// dropped to its minimum, and any Upscale postprocessing step is
+	// stripped (the API has no separate resolution/size parameter to
+	// shrink directly). Once the caller likes a draft, call Promote
+	// with the same params to re-run it at full quality.
+	Draft bool `json:"-"`
 }
 
 // Validate validates the parameters.
@@ -46,6 +88,9 @@ func (p *CreateParams) Validate() error {
 	if err := validator.ValidateScaling(p.TestTimeScaling); err != nil {
 		return err
 	}
+	if err := validator.ValidateNumImages(p.NumImages); err != nil {
+		return err
+	}
 	for _, pp := range p.Postprocess {
 		if err := pp.Validate(); err != nil {
 			return err
@@ -66,30 +111,252 @@ func (p *CreateParams) Validate() error {
 //		log.Fatal(err)
 //	}
 //	err = result.SaveTo("lake.png")
-func (s *Service) Create(ctx context.Context, params *CreateParams) (*types.Result, error) {
+func (s *Service) Create(ctx context.Context, params *CreateParams, opts ...RequestOption) (*types.Result, error) {
 	if params == nil {
 		return nil, validator.ErrEmptyPrompt
 	}
+	if params.AutoStructure {
+		params.Prompt = autoStructurePrompt(params.Prompt)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
+	s.emitLintWarnings(params.Lint())
+	params.Prompt = s.scrubPrompt(params.Prompt)
+	if err := s.screenPrompt(params.Prompt); err != nil {
+		return nil, err
+	}
+	forceFast, err := s.checkFlags(ctx, OpCreate)
+	if err != nil {
+		return nil, err
+	}
+	applyFastModel(&params.Version, forceFast)
+	applyDraftMode(params)
+	if err := s.checkCapabilities(ctx, params.Version, params.AspectRatio, 0, params.Postprocess); err != nil {
+		return nil, err
+	}
+
+	if isBinaryFormat(params.OutputFormat) {
+		key := CacheKey(params.Prompt, params.Seed, params.Version, params.AspectRatio)
+		return s.withCacheAndDedup(ctx, key, func() (*types.Result, error) {
+			return s.withFallback(ctx, params, func() (*types.Result, error) {
+				raw, err := s.CreateRaw(ctx, params, params.OutputFormat, opts...)
+				if err != nil {
+					return nil, err
+				}
+				return rawToResult(raw), nil
+			})
+		})
+	}
 
-	resp, err := s.transport.Do(ctx, &transport.Request{
+	req := &transport.Request{
 		Method:     http.MethodPost,
 		Path:       "/v1/image/create",
 		Body:       params,
 		Breadcrumb: params.Breadcrumb,
+	}
+	applyRequestOptions(req, opts)
+
+	estimate := EstimateCreate(params.TestTimeScaling, params.Postprocess).TotalCredits
+	if err := s.reserveQuota(ctx, req.Partition, estimate); err != nil {
+		return nil, err
+	}
+
+	key := CacheKey(params.Prompt, params.Seed, params.Version, params.AspectRatio)
+	return s.withCacheAndDedup(ctx, key, func() (*types.Result, error) {
+		return s.withFallback(ctx, params, func() (*types.Result, error) {
+			resp, err := s.transport.Do(ctx, req)
+			if err != nil {
+				s.emitTransportError(err)
+				s.releaseQuota(req.Partition, estimate)
+				return nil, err
+			}
+
+			var result types.Result
+			if err := json.Unmarshal(resp.Body, &result); err != nil {
+				s.releaseQuota(req.Partition, estimate)
+				return nil, err
+			}
+			s.recordUsage(req.Partition, estimate, result.CreditsUsed, result.CreditsRemaining)
+			if result.ContentViolation {
+				s.emitEvent("content_violation", "WARN", map[string]string{"request_id": result.RequestID})
+			}
+			result.Region = s.region
+			result.AttemptCount = resp.Attempts
+			result.Latency = resp.Elapsed
+
+			return &result, nil
+		})
 	})
+}
+
+// Promote re-runs params at full quality after the caller has approved
+// a Draft preview, disabling Draft and pinning Seed to draft's if
+// params didn't already set one, so the final image matches the
+// preview as closely as the model allows. params is not modified; a
+// copy is sent. Pass the params value as originally built, not the
+// pointer already given to the draft Create call -- like the rest of
+// this SDK's routing options, Draft overrides Version, TestTimeScaling,
+// and Postprocess in place, so a pointer already used for the draft
+// call has lost its pre-Draft values.
+//
+// Example:
+//
+//	params := &image.CreateParams{
+//		Prompt:      "a ceramic mug, studio lighting",
+//		Postprocess: []types.Postprocess{types.Upscale(2)},
+//		Draft:       true,
+//	}
+//	draft, _ := client.Images.Create(ctx, params)
+//	// ... caller reviews draft ...
+//	final, err := client.Images.Promote(ctx, draft, &image.CreateParams{
+//		Prompt:      "a ceramic mug, studio lighting",
+//		Postprocess: []types.Postprocess{types.Upscale(2)},
+//	})
+func (s *Service) Promote(ctx context.Context, draft *types.Result, params *CreateParams, opts ...RequestOption) (*types.Result, error) {
+	if params == nil {
+		return nil, validator.ErrEmptyPrompt
+	}
+	full := *params
+	full.Draft = false
+	if full.Seed == 0 && draft != nil {
+		full.Seed = draft.Seed
+	}
+	return s.Create(ctx, &full, opts...)
+}
+
+// createMultiWire is the wire envelope for a CreateMulti response: the
+// same shared metadata as a single-image Result, but images as a list.
+type createMultiWire struct {
+	Images           []string `json:"images"`
+	Version          string   `json:"version"`
+	ContentViolation bool     `json:"content_violation"`
+	RequestID        string   `json:"request_id"`
+	CreditsUsed      int      `json:"credits_used"`
+	CreditsRemaining int      `json:"credits_remaining"`
+	Seed             int64    `json:"seed"`
+}
+
+// CreateMulti generates params.NumImages candidate images in a single
+// round trip, returning all of them. Use it instead of Create when
+// NumImages is greater than 1.
+//
+// Example:
+//
+//	multi, err := client.Images.CreateMulti(ctx, &image.CreateParams{
+//		Prompt:    "a ceramic mug, studio lighting",
+//		NumImages: 4,
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = multi.SaveAllTo("out", "mug_*.png")
+func (s *Service) CreateMulti(ctx context.Context, params *CreateParams, opts ...RequestOption) (*types.MultiResult, error) {
+	if params == nil {
+		return nil, validator.ErrEmptyPrompt
+	}
+	if params.AutoStructure {
+		params.Prompt = autoStructurePrompt(params.Prompt)
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	s.emitLintWarnings(params.Lint())
+	params.Prompt = s.scrubPrompt(params.Prompt)
+	if err := s.screenPrompt(params.Prompt); err != nil {
+		return nil, err
+	}
+	forceFast, err := s.checkFlags(ctx, OpCreate)
+	if err != nil {
+		return nil, err
+	}
+	applyFastModel(&params.Version, forceFast)
+	applyDraftMode(params)
+	if err := s.checkCapabilities(ctx, params.Version, params.AspectRatio, params.NumImages, params.Postprocess); err != nil {
+		return nil, err
+	}
+
+	req := &transport.Request{
+		Method:     http.MethodPost,
+		Path:       "/v1/image/create",
+		Body:       params,
+		Breadcrumb: params.Breadcrumb,
+	}
+	applyRequestOptions(req, opts)
+
+	estimate := EstimateCreate(params.TestTimeScaling, params.Postprocess).TotalCredits * params.NumImages
+	if err := s.reserveQuota(ctx, req.Partition, estimate); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.transport.Do(ctx, req)
 	if err != nil {
+		s.emitTransportError(err)
+		s.releaseQuota(req.Partition, estimate)
 		return nil, err
 	}
 
-	var result types.Result
-	if err := json.Unmarshal(resp.Body, &result); err != nil {
+	var wire createMultiWire
+	if err := json.Unmarshal(resp.Body, &wire); err != nil {
+		s.releaseQuota(req.Partition, estimate)
 		return nil, err
 	}
+	s.recordUsage(req.Partition, estimate, wire.CreditsUsed, wire.CreditsRemaining)
+	if wire.ContentViolation {
+		s.emitEvent("content_violation", "WARN", map[string]string{"request_id": wire.RequestID})
+	}
 
-	return &result, nil
+	multi := &types.MultiResult{}
+	for i, img := range wire.Images {
+		if img == "" {
+			multi.Errors = append(multi.Errors, types.ImageError{Index: i, Err: errEmptyImage})
+			continue
+		}
+		if _, err := base64.StdEncoding.DecodeString(img); err != nil {
+			multi.Errors = append(multi.Errors, types.ImageError{Index: i, Err: err})
+			continue
+		}
+		multi.Results = append(multi.Results, types.Result{
+			Image:            img,
+			Version:          wire.Version,
+			ContentViolation: wire.ContentViolation,
+			RequestID:        wire.RequestID,
+			CreditsUsed:      wire.CreditsUsed,
+			CreditsRemaining: wire.CreditsRemaining,
+			Seed:             wire.Seed,
+			Index:            i,
+			Region:           s.region,
+			AttemptCount:     resp.Attempts,
+			Latency:          resp.Elapsed,
+		})
+	}
+
+	return multi, nil
+}
+
+// isBinaryFormat reports whether format requests a binary image encoding
+// rather than the default JSON envelope.
+func isBinaryFormat(format types.OutputFormat) bool {
+	return format != "" && format != types.FormatJSON
+}
+
+// rawToResult adapts a RawResult into the unified *types.Result shape by
+// base64 encoding its bytes, so callers of Create always get one return
+// type regardless of the wire format actually negotiated.
+func rawToResult(raw *types.RawResult) *types.Result {
+	img := types.NewImage(raw.Data)
+	return &types.Result{
+		Image:            img.Base64(),
+		Version:          raw.Version,
+		ContentViolation: raw.ContentViolation,
+		RequestID:        raw.RequestID,
+		CreditsUsed:      raw.CreditsUsed,
+		CreditsRemaining: raw.CreditsRemaining,
+		Seed:             raw.Seed,
+		Region:           raw.Region,
+		AttemptCount:     raw.AttemptCount,
+		Latency:          raw.Latency,
+	}
 }
 
 // CreateRaw generates an image and returns raw bytes.
@@ -103,28 +370,59 @@ func (s *Service) Create(ctx context.Context, params *CreateParams) (*types.Resu
 //		log.Fatal(err)
 //	}
 //	err = result.SaveTo("sunset.png")
-func (s *Service) CreateRaw(ctx context.Context, params *CreateParams, format types.OutputFormat) (*types.RawResult, error) {
+func (s *Service) CreateRaw(ctx context.Context, params *CreateParams, format types.OutputFormat, opts ...RequestOption) (*types.RawResult, error) {
 	if params == nil {
 		return nil, validator.ErrEmptyPrompt
 	}
+	if params.AutoStructure {
+		params.Prompt = autoStructurePrompt(params.Prompt)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
+	s.emitLintWarnings(params.Lint())
+	params.Prompt = s.scrubPrompt(params.Prompt)
+	if err := s.screenPrompt(params.Prompt); err != nil {
+		return nil, err
+	}
+	forceFast, err := s.checkFlags(ctx, OpCreate)
+	if err != nil {
+		return nil, err
+	}
+	applyFastModel(&params.Version, forceFast)
+	applyDraftMode(params)
+	if err := s.checkCapabilities(ctx, params.Version, params.AspectRatio, 0, params.Postprocess); err != nil {
+		return nil, err
+	}
 
 	if format == "" || format == types.FormatJSON {
 		format = types.FormatPNG
 	}
 
-	resp, err := s.transport.DoRaw(ctx, &transport.Request{
+	req := &transport.Request{
 		Method:     http.MethodPost,
 		Path:       "/v1/image/create",
 		Body:       params,
 		Accept:     string(format),
 		Breadcrumb: params.Breadcrumb,
-	})
+	}
+	applyRequestOptions(req, opts)
+
+	estimate := EstimateCreate(params.TestTimeScaling, params.Postprocess).TotalCredits
+	if err := s.reserveQuota(ctx, req.Partition, estimate); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.transport.DoRaw(ctx, req)
 	if err != nil {
+		s.emitTransportError(err)
+		s.releaseQuota(req.Partition, estimate)
 		return nil, err
 	}
+	s.recordUsage(req.Partition, estimate, resp.CreditsUsed, resp.CreditsRemaining)
+	if resp.ContentViolation {
+		s.emitEvent("content_violation", "WARN", map[string]string{"request_id": resp.RequestID})
+	}
 
 	return &types.RawResult{
 		Data:             resp.Data,
@@ -134,5 +432,11 @@ func (s *Service) CreateRaw(ctx context.Context, params *CreateParams, format ty
 		RequestID:        resp.RequestID,
 		CreditsUsed:      resp.CreditsUsed,
 		CreditsRemaining: resp.CreditsRemaining,
+		ETag:             resp.ETag,
+		NotModified:      resp.NotModified,
+		Seed:             resp.Seed,
+		Region:           s.region,
+		AttemptCount:     resp.Attempts,
+		Latency:          resp.Elapsed,
 	}, nil
 }
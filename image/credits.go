@@ -0,0 +1,37 @@
+package image
+
+import (
+	"sync"
+	"time"
+)
+
+// creditsSnapshot holds the CreditsRemaining from the most recent
+// Create, Edit, or Remix response, so it can be read concurrently
+// without an extra API call.
+type creditsSnapshot struct {
+	mu         sync.Mutex
+	remaining  int
+	recordedAt time.Time
+}
+
+func (c *creditsSnapshot) record(remaining int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.remaining = remaining
+	c.recordedAt = time.Now()
+}
+
+func (c *creditsSnapshot) get() (int, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.remaining, c.recordedAt
+}
+
+// CreditsRemaining returns the CreditsRemaining reported by the most
+// recent Create, Edit, or Remix response, and when it was recorded.
+// The zero time means no response has come back yet. Safe for
+// concurrent use; lets dashboards and admission control read the
+// balance without spending an API call to check it.
+func (s *Service) CreditsRemaining() (int, time.Time) {
+	return s.credits.get()
+}
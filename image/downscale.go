@@ -0,0 +1,87 @@
+package image
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"math"
+)
+
+// downscaleReferenceImage returns img downscaled to
+// s.autoDownscaleMegapixels when SetAutoDownscale is enabled and img
+// decodes as PNG or JPEG and is over that resolution. Returns img
+// unchanged otherwise -- including when it's not PNG/JPEG, or fails to
+// decode -- so Validate still reports a clear error for a genuinely bad
+// or oversized WebP payload.
+func (s *Service) downscaleReferenceImage(img string) string {
+	if s.autoDownscaleMegapixels <= 0 {
+		return img
+	}
+	scaled, ok := downscaleToMegapixels(img, s.autoDownscaleMegapixels)
+	if !ok {
+		return img
+	}
+	return scaled
+}
+
+// downscaleToMegapixels base64-decodes a PNG or JPEG image, and if its
+// resolution exceeds maxMegapixels, resizes it down to fit using
+// nearest-neighbor sampling and re-encodes it in its original format.
+// ok is false if encoded isn't valid base64, isn't PNG/JPEG, or fails to
+// decode or re-encode.
+func downscaleToMegapixels(encoded string, maxMegapixels float64) (scaled string, ok bool) {
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", false
+	}
+
+	var img image.Image
+	var encode func(buf *bytes.Buffer, img image.Image) error
+	switch {
+	case bytes.HasPrefix(decoded, []byte{0x89, 'P', 'N', 'G'}):
+		img, err = png.Decode(bytes.NewReader(decoded))
+		encode = func(buf *bytes.Buffer, img image.Image) error { return png.Encode(buf, img) }
+	case bytes.HasPrefix(decoded, []byte{0xFF, 0xD8, 0xFF}):
+		img, err = jpeg.Decode(bytes.NewReader(decoded))
+		encode = func(buf *bytes.Buffer, img image.Image) error { return jpeg.Encode(buf, img, nil) }
+	default:
+		return "", false
+	}
+	if err != nil {
+		return "", false
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	megapixels := float64(width*height) / 1_000_000
+	if megapixels <= maxMegapixels {
+		return "", false
+	}
+
+	scale := math.Sqrt(maxMegapixels / megapixels)
+	resized := resizeNearestNeighbor(img, int(float64(width)*scale), int(float64(height)*scale))
+
+	var buf bytes.Buffer
+	if err := encode(&buf, resized); err != nil {
+		return "", false
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), true
+}
+
+// resizeNearestNeighbor resizes src to width x height by nearest-
+// neighbor sampling. The standard library has no image scaler; this is
+// the simplest one that needs no new dependency.
+func resizeNearestNeighbor(src image.Image, width, height int) image.Image {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		sy := bounds.Min.Y + y*bounds.Dy()/height
+		for x := 0; x < width; x++ {
+			sx := bounds.Min.X + x*bounds.Dx()/width
+			dst.Set(x, y, src.At(sx, sy))
+		}
+	}
+	return dst
+}
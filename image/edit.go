@@ -34,6 +34,13 @@ type EditParams struct {
 	// Default: 1
 	TestTimeScaling float64 `json:"test_time_scaling,omitempty"`
 
+	// OutputFormat requests a specific binary format via the Accept header.
+	// When set to an image MIME type (e.g. types.FormatWebP), Edit
+	// transparently fetches the image through the raw path and base64
+	// encodes it into Result.Image, so callers keep using the unified
+	// *types.Result return type regardless of wire format.
+	OutputFormat types.OutputFormat `json:"-"`
+
 	// Breadcrumb is an optional tracking ID.
 	Breadcrumb string `json:"-"`
 }
@@ -73,30 +80,83 @@ func (p *EditParams) Validate() error {
 //		log.Fatal(err)
 //	}
 //	err = result.SaveTo("watercolor.png")
-func (s *Service) Edit(ctx context.Context, params *EditParams) (*types.Result, error) {
+func (s *Service) Edit(ctx context.Context, params *EditParams, opts ...RequestOption) (*types.Result, error) {
 	if params == nil {
 		return nil, validator.ErrEmptyInstruction
 	}
+	params.ReferenceImage = s.downscaleReferenceImage(params.ReferenceImage)
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
+	s.emitLintWarnings(params.Lint())
+	params.Instruction = s.scrubPrompt(params.Instruction)
+	if err := s.screenPrompt(params.Instruction); err != nil {
+		return nil, err
+	}
+	forceFast, err := s.checkFlags(ctx, OpEdit)
+	if err != nil {
+		return nil, err
+	}
+	applyFastModel(&params.Version, forceFast)
+	if err := s.checkCapabilities(ctx, params.Version, params.AspectRatio, 0, params.Postprocess); err != nil {
+		return nil, err
+	}
 
-	resp, err := s.transport.Do(ctx, &transport.Request{
+	if isBinaryFormat(params.OutputFormat) {
+		key := CacheKey(params.Instruction+"\x00"+params.ReferenceImage, 0, params.Version, params.AspectRatio)
+		return s.withCacheAndDedup(ctx, key, func() (*types.Result, error) {
+			return s.withFallback(ctx, params, func() (*types.Result, error) {
+				raw, err := s.EditRaw(ctx, params, params.OutputFormat, opts...)
+				if err != nil {
+					return nil, err
+				}
+				return rawToResult(raw), nil
+			})
+		})
+	}
+
+	req := &transport.Request{
 		Method:     http.MethodPost,
 		Path:       "/v1/image/edit",
 		Body:       params,
 		Breadcrumb: params.Breadcrumb,
-	})
-	if err != nil {
+	}
+	applyRequestOptions(req, opts)
+	if err := s.attachReferenceImage(req, "reference_image", params.ReferenceImage); err != nil {
 		return nil, err
 	}
 
-	var result types.Result
-	if err := json.Unmarshal(resp.Body, &result); err != nil {
+	estimate := EstimateEdit(params.Version == types.VersionEditFast20251030, params.TestTimeScaling, params.Postprocess).TotalCredits
+	if err := s.reserveQuota(ctx, req.Partition, estimate); err != nil {
 		return nil, err
 	}
 
-	return &result, nil
+	key := CacheKey(params.Instruction+"\x00"+params.ReferenceImage, 0, params.Version, params.AspectRatio)
+	return s.withCacheAndDedup(ctx, key, func() (*types.Result, error) {
+		return s.withFallback(ctx, params, func() (*types.Result, error) {
+			resp, err := s.transport.Do(ctx, req)
+			if err != nil {
+				s.emitTransportError(err)
+				s.releaseQuota(req.Partition, estimate)
+				return nil, err
+			}
+
+			var result types.Result
+			if err := json.Unmarshal(resp.Body, &result); err != nil {
+				s.releaseQuota(req.Partition, estimate)
+				return nil, err
+			}
+			s.recordUsage(req.Partition, estimate, result.CreditsUsed, result.CreditsRemaining)
+			if result.ContentViolation {
+				s.emitEvent("content_violation", "WARN", map[string]string{"request_id": result.RequestID})
+			}
+			result.Region = s.region
+			result.AttemptCount = resp.Attempts
+			result.Latency = resp.Elapsed
+
+			return &result, nil
+		})
+	})
 }
 
 // EditRaw modifies an image and returns raw bytes.
@@ -109,28 +169,59 @@ func (s *Service) Edit(ctx context.Context, params *EditParams) (*types.Result,
 //		ReferenceImage: img.Base64(),
 //		Version:        types.VersionLatestFast,
 //	}, types.FormatJPEG)
-func (s *Service) EditRaw(ctx context.Context, params *EditParams, format types.OutputFormat) (*types.RawResult, error) {
+func (s *Service) EditRaw(ctx context.Context, params *EditParams, format types.OutputFormat, opts ...RequestOption) (*types.RawResult, error) {
 	if params == nil {
 		return nil, validator.ErrEmptyInstruction
 	}
+	params.ReferenceImage = s.downscaleReferenceImage(params.ReferenceImage)
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
+	s.emitLintWarnings(params.Lint())
+	params.Instruction = s.scrubPrompt(params.Instruction)
+	if err := s.screenPrompt(params.Instruction); err != nil {
+		return nil, err
+	}
+	forceFast, err := s.checkFlags(ctx, OpEdit)
+	if err != nil {
+		return nil, err
+	}
+	applyFastModel(&params.Version, forceFast)
+	if err := s.checkCapabilities(ctx, params.Version, params.AspectRatio, 0, params.Postprocess); err != nil {
+		return nil, err
+	}
 
 	if format == "" || format == types.FormatJSON {
 		format = types.FormatPNG
 	}
 
-	resp, err := s.transport.DoRaw(ctx, &transport.Request{
+	req := &transport.Request{
 		Method:     http.MethodPost,
 		Path:       "/v1/image/edit",
 		Body:       params,
 		Accept:     string(format),
 		Breadcrumb: params.Breadcrumb,
-	})
+	}
+	applyRequestOptions(req, opts)
+	if err := s.attachReferenceImage(req, "reference_image", params.ReferenceImage); err != nil {
+		return nil, err
+	}
+
+	estimate := EstimateEdit(params.Version == types.VersionEditFast20251030, params.TestTimeScaling, params.Postprocess).TotalCredits
+	if err := s.reserveQuota(ctx, req.Partition, estimate); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.transport.DoRaw(ctx, req)
 	if err != nil {
+		s.emitTransportError(err)
+		s.releaseQuota(req.Partition, estimate)
 		return nil, err
 	}
+	s.recordUsage(req.Partition, estimate, resp.CreditsUsed, resp.CreditsRemaining)
+	if resp.ContentViolation {
+		s.emitEvent("content_violation", "WARN", map[string]string{"request_id": resp.RequestID})
+	}
 
 	return &types.RawResult{
 		Data:             resp.Data,
@@ -140,5 +231,11 @@ func (s *Service) EditRaw(ctx context.Context, params *EditParams, format types.
 		RequestID:        resp.RequestID,
 		CreditsUsed:      resp.CreditsUsed,
 		CreditsRemaining: resp.CreditsRemaining,
+		ETag:             resp.ETag,
+		NotModified:      resp.NotModified,
+		Seed:             resp.Seed,
+		Region:           s.region,
+		AttemptCount:     resp.Attempts,
+		Latency:          resp.Elapsed,
 	}, nil
 }
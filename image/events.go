@@ -0,0 +1,52 @@
+package image
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shamspias/reve-go/internal/transport"
+)
+
+// Event is a notable occurrence worth forwarding to an observability or
+// SIEM pipeline: a content policy violation, a quota partition running
+// out of budget, or the circuit breaker opening.
+type Event struct {
+	// Name identifies the kind of event: "content_violation",
+	// "quota_exceeded", or "circuit_open".
+	Name string
+
+	// Time is when the event happened.
+	Time time.Time
+
+	// Severity mirrors OTel's SeverityText values ("INFO", "WARN",
+	// "ERROR").
+	Severity string
+
+	// Attributes carries event-specific context, e.g. RequestID or
+	// Partition.
+	Attributes map[string]string
+}
+
+// EventSink receives Event values as they happen. See the bundled
+// otellog package for an OTel Log Data Model-shaped implementation, and
+// reve.WithEventSink to install one.
+type EventSink interface {
+	Emit(event Event)
+}
+
+// emitEvent reports an Event to s.events, if any.
+func (s *Service) emitEvent(name, severity string, attrs map[string]string) {
+	if s.events == nil {
+		return
+	}
+	s.events.Emit(Event{Name: name, Time: time.Now(), Severity: severity, Attributes: attrs})
+}
+
+// emitTransportError reports a circuit_open event when err came from an
+// open circuit breaker, so operators see the outage in their
+// observability pipeline, not just in a failed call's error value.
+func (s *Service) emitTransportError(err error) {
+	if errors.Is(err, transport.ErrCircuitOpen) {
+		s.emitEvent("circuit_open", "ERROR", nil)
+	}
+}
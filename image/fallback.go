@@ -0,0 +1,26 @@
+package image
+
+import (
+	"context"
+
+	"github.com/shamspias/reve-go/types"
+)
+
+// Fallback is invoked by Create, Edit, and Remix after the underlying
+// request has exhausted retries and failed, letting a caller serve a
+// cached or placeholder *types.Result instead of propagating the error,
+// so user-facing pages degrade instead of erroring during an outage.
+// params is the *CreateParams, *EditParams, or *RemixParams that failed.
+// Returning a non-nil error from Fallback surfaces that error instead of
+// the original one.
+type Fallback func(ctx context.Context, params any) (*types.Result, error)
+
+// withFallback runs fn and, if it fails, calls s.fallback (when set) to
+// produce a degraded result instead of the error.
+func (s *Service) withFallback(ctx context.Context, params any, fn func() (*types.Result, error)) (*types.Result, error) {
+	result, err := fn()
+	if err == nil || s.fallback == nil {
+		return result, err
+	}
+	return s.fallback(ctx, params)
+}
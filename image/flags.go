@@ -0,0 +1,88 @@
+package image
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shamspias/reve-go/types"
+)
+
+// Operation identifies one of the Service's request kinds, for
+// FlagProvider checks that apply to only some operations (e.g.
+// disabling Remix without touching Create or Edit).
+type Operation string
+
+// Operations a FlagProvider can be consulted about.
+const (
+	OpCreate Operation = "create"
+	OpEdit   Operation = "edit"
+	OpRemix  Operation = "remix"
+)
+
+// FlagProvider is consulted before every Create, Edit, and Remix call,
+// letting operators change behavior at runtime (e.g. via LaunchDarkly,
+// Unleash, or a config file) without redeploying callers. See
+// reve.WithFeatureFlags.
+type FlagProvider interface {
+	// Disabled reports whether op should be rejected instead of sent,
+	// e.g. to take a feature offline during an incident.
+	Disabled(ctx context.Context, op Operation) bool
+
+	// ForceFastModel reports whether every request should be routed to
+	// its fast model variant regardless of the caller's requested
+	// Version, e.g. to shed load during a capacity incident.
+	ForceFastModel(ctx context.Context) bool
+}
+
+// ErrOperationDisabled is returned when a FlagProvider disables the
+// requested operation.
+type ErrOperationDisabled struct {
+	Operation Operation
+}
+
+// Error implements the error interface.
+func (e *ErrOperationDisabled) Error() string {
+	return fmt.Sprintf("reve: %s is disabled by feature flag", e.Operation)
+}
+
+// checkFlags returns ErrOperationDisabled if op is disabled, and
+// otherwise reports whether version should be forced to its fast
+// variant.
+func (s *Service) checkFlags(ctx context.Context, op Operation) (forceFast bool, err error) {
+	if s.flags == nil {
+		return false, nil
+	}
+	if s.flags.Disabled(ctx, op) {
+		return false, &ErrOperationDisabled{Operation: op}
+	}
+	return s.flags.ForceFastModel(ctx), nil
+}
+
+// applyFastModel overrides version with its fast variant when force is
+// set, leaving it unchanged otherwise.
+func applyFastModel(version *types.ModelVersion, force bool) {
+	if force {
+		*version = types.VersionLatestFast
+	}
+}
+
+// applyDraftMode reroutes a CreateParams.Draft request to the fast
+// model variant, drops TestTimeScaling to its minimum, and strips any
+// Upscale postprocessing step, so preview calls in an
+// iterate-then-finalize workflow are as cheap and fast as this SDK can
+// make them. A no-op when Draft is false.
+func applyDraftMode(params *CreateParams) {
+	if !params.Draft {
+		return
+	}
+	params.Version = types.VersionLatestFast
+	params.TestTimeScaling = 1
+
+	kept := params.Postprocess[:0:0]
+	for _, pp := range params.Postprocess {
+		if pp.Process != types.ProcessUpscale {
+			kept = append(kept, pp)
+		}
+	}
+	params.Postprocess = kept
+}
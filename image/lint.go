@@ -0,0 +1,125 @@
+package image
+
+import (
+	"fmt"
+
+	"github.com/shamspias/reve-go/internal/validator"
+	"github.com/shamspias/reve-go/types"
+)
+
+// Warning is a non-fatal observation about a params value -- something
+// that will send fine, but is often a mistake or an expensive choice.
+// See CreateParams.Lint, EditParams.Lint, and RemixParams.Lint.
+type Warning struct {
+	// Field names the param the warning is about, e.g. "Prompt" or
+	// "TestTimeScaling".
+	Field string
+
+	// Message describes the concern.
+	Message string
+}
+
+// String formats the warning as "Field: Message".
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Field, w.Message)
+}
+
+// promptLengthWarningThreshold is the fraction of validator.MaxPromptLength
+// at which a prompt is flagged as close to the limit.
+const promptLengthWarningThreshold = 0.9
+
+// fastModelHighScalingThreshold is the TestTimeScaling value above
+// which pairing it with a fast model variant is flagged as
+// contradictory: fast models trade quality for speed, and a high
+// TestTimeScaling asks for the opposite.
+const fastModelHighScalingThreshold = 5.0
+
+// lintPrompt warns when prompt is close to validator.MaxPromptLength,
+// where an otherwise-small edit could push it over and start failing
+// Validate.
+func lintPrompt(field, prompt string) []Warning {
+	var warnings []Warning
+	length := validator.PromptLength(prompt)
+	if length >= int(float64(validator.MaxPromptLength)*promptLengthWarningThreshold) {
+		warnings = append(warnings, Warning{
+			Field:   field,
+			Message: fmt.Sprintf("%d of %d characters used, close to the limit", length, validator.MaxPromptLength),
+		})
+	}
+	return warnings
+}
+
+// lintScaling warns when version is a fast model but scaling asks for
+// high quality, a contradictory and wasted combination.
+func lintScaling(version types.ModelVersion, scaling float64) []Warning {
+	if version.IsFast() && scaling > fastModelHighScalingThreshold {
+		return []Warning{{
+			Field:   "TestTimeScaling",
+			Message: fmt.Sprintf("%.0f requests high quality, but %s is a fast model chosen for speed -- the two work against each other", scaling, version),
+		}}
+	}
+	return nil
+}
+
+// lintAutoUpscale warns about auto aspect ratio combined with a 4x
+// upscale, an expensive combination: the model picks a ratio it has no
+// guarantee fits well with a subsequent 4x enlargement.
+func lintAutoUpscale(ratio types.AspectRatio, postprocess []types.Postprocess) []Warning {
+	if ratio != types.RatioAuto {
+		return nil
+	}
+	for _, pp := range postprocess {
+		if pp.Process == types.ProcessUpscale && pp.UpscaleFactor == 4 {
+			return []Warning{{
+				Field:   "AspectRatio",
+				Message: "auto aspect ratio with a 4x upscale is expensive and the model's chosen ratio isn't guaranteed to upscale well -- consider pinning AspectRatio explicitly",
+			}}
+		}
+	}
+	return nil
+}
+
+// Lint returns non-fatal warnings about p: a prompt near the length
+// limit, a fast model paired with a high TestTimeScaling, or an auto
+// aspect ratio with a 4x upscale. Unlike Validate, a non-empty result
+// doesn't mean the request will fail.
+func (p *CreateParams) Lint() []Warning {
+	var warnings []Warning
+	warnings = append(warnings, lintPrompt("Prompt", p.Prompt)...)
+	warnings = append(warnings, lintScaling(p.Version, p.TestTimeScaling)...)
+	warnings = append(warnings, lintAutoUpscale(p.AspectRatio, p.Postprocess)...)
+	return warnings
+}
+
+// Lint returns non-fatal warnings about p: an instruction near the
+// length limit, a fast model paired with a high TestTimeScaling, or an
+// auto aspect ratio with a 4x upscale. Unlike Validate, a non-empty
+// result doesn't mean the request will fail.
+func (p *EditParams) Lint() []Warning {
+	var warnings []Warning
+	warnings = append(warnings, lintPrompt("Instruction", p.Instruction)...)
+	warnings = append(warnings, lintScaling(p.Version, p.TestTimeScaling)...)
+	warnings = append(warnings, lintAutoUpscale(p.AspectRatio, p.Postprocess)...)
+	return warnings
+}
+
+// Lint returns non-fatal warnings about p: a prompt near the length
+// limit, a fast model paired with a high TestTimeScaling, or an auto
+// aspect ratio with a 4x upscale. Unlike Validate, a non-empty result
+// doesn't mean the request will fail.
+func (p *RemixParams) Lint() []Warning {
+	var warnings []Warning
+	warnings = append(warnings, lintPrompt("Prompt", p.Prompt)...)
+	warnings = append(warnings, lintScaling(p.Version, p.TestTimeScaling)...)
+	warnings = append(warnings, lintAutoUpscale(p.AspectRatio, p.Postprocess)...)
+	return warnings
+}
+
+// emitLintWarnings reports each of warnings as a lint_warning Event, so
+// an installed EventSink sees misconfigurations without every caller
+// having to call Lint itself. A no-op when no EventSink is installed.
+func (s *Service) emitLintWarnings(warnings []Warning) {
+	for _, w := range warnings {
+		s.emitEvent("lint_warning", "INFO", map[string]string{"field": w.Field, "message": w.Message})
+	}
+}
@@ -0,0 +1,202 @@
+package image
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/shamspias/reve-go/types"
+)
+
+// RowError describes a single input row that failed to parse or
+// validate, returned alongside the rows that did succeed so a large
+// catalog job can report and skip bad entries instead of aborting.
+type RowError struct {
+	// Line is the 1-indexed source line (JSONL) or data row (CSV,
+	// excluding the header) the error came from.
+	Line int
+
+	// Err is the parse or validation failure.
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error { return e.Err }
+
+// LoadBatchFromJSONL reads path as newline-delimited JSON, one
+// CreateParams object per line (using the same field names as the API,
+// e.g. {"prompt": "...", "aspect_ratio": "16:9"}), for feeding
+// BatchCreate or GenerateSeq from a prompt file instead of code.
+// Malformed or invalid lines are collected as RowErrors rather than
+// aborting the load; blank lines are skipped.
+func LoadBatchFromJSONL(path string) ([]*CreateParams, []error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, []error{err}
+	}
+	defer f.Close()
+
+	var params []*CreateParams
+	var errs []error
+
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if text == "" {
+			continue
+		}
+
+		p := &CreateParams{}
+		if err := json.Unmarshal([]byte(text), p); err != nil {
+			errs = append(errs, &RowError{Line: line, Err: err})
+			continue
+		}
+		if err := p.Validate(); err != nil {
+			errs = append(errs, &RowError{Line: line, Err: err})
+			continue
+		}
+		params = append(params, p)
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return params, errs
+}
+
+// csvColumns are the recognized CSV header names for LoadBatchFromCSV.
+// Only "prompt" is required; the rest default to their CreateParams
+// zero value when the column is absent.
+var csvColumns = []string{"prompt", "aspect_ratio", "version", "seed", "num_images", "test_time_scaling"}
+
+// LoadBatchFromCSV reads path as a CSV file with a header row drawn
+// from csvColumns ("prompt", "aspect_ratio", "version", "seed",
+// "num_images", "test_time_scaling"), in any order, for driving a batch
+// from a spreadsheet export. Malformed or invalid rows are collected as
+// RowErrors rather than aborting the load.
+func LoadBatchFromCSV(path string) ([]*CreateParams, []error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, []error{err}
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, []error{fmt.Errorf("read header: %w", err)}
+	}
+	col := make(map[string]int, len(header))
+	for i, name := range header {
+		col[name] = i
+	}
+	if _, ok := col["prompt"]; !ok {
+		return nil, []error{fmt.Errorf("csv: missing required %q column", "prompt")}
+	}
+
+	var params []*CreateParams
+	var errs []error
+
+	line := 0
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			errs = append(errs, &RowError{Line: line, Err: err})
+			continue
+		}
+
+		p := &CreateParams{Prompt: field(record, col, "prompt")}
+		p.AspectRatio = types.AspectRatio(field(record, col, "aspect_ratio"))
+		p.Version = types.ModelVersion(field(record, col, "version"))
+
+		if v := field(record, col, "seed"); v != "" {
+			seed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				errs = append(errs, &RowError{Line: line, Err: fmt.Errorf("seed: %w", err)})
+				continue
+			}
+			p.Seed = seed
+		}
+		if v := field(record, col, "num_images"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				errs = append(errs, &RowError{Line: line, Err: fmt.Errorf("num_images: %w", err)})
+				continue
+			}
+			p.NumImages = n
+		}
+		if v := field(record, col, "test_time_scaling"); v != "" {
+			scaling, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				errs = append(errs, &RowError{Line: line, Err: fmt.Errorf("test_time_scaling: %w", err)})
+				continue
+			}
+			p.TestTimeScaling = scaling
+		}
+
+		if err := p.Validate(); err != nil {
+			errs = append(errs, &RowError{Line: line, Err: err})
+			continue
+		}
+		params = append(params, p)
+	}
+	return params, errs
+}
+
+// field returns record's value for the named column, or "" if the
+// column wasn't present in the header or the row is short.
+func field(record []string, col map[string]int, name string) string {
+	i, ok := col[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return record[i]
+}
+
+// WriteBatchReport writes a CSV report of a completed batch to w, one
+// row per result: index, path (from paths, matched by index; blank if
+// not provided or the request failed), request_id, credits_used, and
+// error, so a catalog-generation job has an audit trail of what was
+// produced and what wasn't. paths may be nil or shorter than results.
+func WriteBatchReport(w io.Writer, results []BatchResult, paths []string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"index", "path", "request_id", "credits_used", "error"}); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		path := ""
+		if r.Index < len(paths) && r.Error == nil {
+			path = paths[r.Index]
+		}
+
+		row := []string{strconv.Itoa(r.Index), path, "", "", ""}
+		if r.Result != nil {
+			row[2] = r.Result.RequestID
+			row[3] = strconv.Itoa(r.Result.CreditsUsed)
+		}
+		if r.Error != nil {
+			row[4] = r.Error.Error()
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,40 @@
+package image
+
+import "github.com/shamspias/reve-go/internal/transport"
+
+// Progress receives lifecycle callbacks for a running batch or a large
+// raw download, so a CLI or UI can render a progress bar and ETA
+// instead of leaving the terminal silent for the duration of a run.
+// Implementations must be safe for concurrent use: OnItemStart and
+// OnItemDone are called from worker goroutines in BatchCreate,
+// BatchEdit, and BatchRemix.
+type Progress interface {
+	// OnItemStart is called when item index begins processing.
+	OnItemStart(index int)
+
+	// OnItemDone is called when item index finishes, with the error it
+	// finished with, if any.
+	OnItemDone(index int, err error)
+
+	// OnBytes is called as a raw download's response body is read, with
+	// the cumulative bytes read so far and the total from Content-Length
+	// (0 if the server didn't send one). Only consulted by CreateRaw,
+	// EditRaw, and RemixRaw via WithProgress.
+	OnBytes(read, total int64)
+}
+
+// WithProgress reports byte-level download progress for this raw
+// request to p, via p.OnBytes. Only meaningful on CreateRaw, EditRaw,
+// and RemixRaw; ignored by the non-raw methods, which don't stream a
+// binary body.
+//
+// Example:
+//
+//	raw, err := client.Images.CreateRaw(ctx, params, types.FormatPNG,
+//		image.WithProgress(bar),
+//	)
+func WithProgress(p Progress) RequestOption {
+	return func(r *transport.Request) {
+		r.OnBytes = p.OnBytes
+	}
+}
@@ -0,0 +1,70 @@
+package image
+
+import (
+	"context"
+
+	"github.com/shamspias/reve-go/types"
+)
+
+// Phase identifies a stage in a Create call's lifecycle, reported by
+// CreateWithProgress.
+//
+// Reve documents no SSE or other streaming progress endpoint, so there
+// is no server-reported queue position, percent-complete, or preview
+// thumbnail to relay here -- Create is a single synchronous call that
+// either returns a finished result or fails. These are the genuine
+// local phase transitions the SDK itself observes around that call;
+// callers wanting byte-level download progress on a binary result
+// instead should use WithProgress on CreateRaw.
+type Phase int
+
+const (
+	// PhaseQueued is reported once, before the request is sent, so a UI
+	// has something to show immediately.
+	PhaseQueued Phase = iota
+
+	// PhaseRequesting is reported once, just before Create is called,
+	// and covers its validation and every retry and hedge attempt until
+	// a response (or a final error) comes back.
+	PhaseRequesting
+
+	// PhaseDone is reported once Create has returned, successfully or
+	// not; check the error CreateWithProgress returns to tell which.
+	PhaseDone
+)
+
+// String returns a human-readable name for p.
+func (p Phase) String() string {
+	switch p {
+	case PhaseQueued:
+		return "queued"
+	case PhaseRequesting:
+		return "requesting"
+	case PhaseDone:
+		return "done"
+	default:
+		return "unknown"
+	}
+}
+
+// ProgressEvent is reported to CreateWithProgress's callback as Create's
+// lifecycle advances.
+type ProgressEvent struct {
+	Phase Phase
+}
+
+// CreateWithProgress runs Create, reporting each Phase to onEvent as it
+// happens. A nil onEvent is a no-op. See Phase for what is and is not
+// available to report.
+func (s *Service) CreateWithProgress(ctx context.Context, params *CreateParams, onEvent func(ProgressEvent), opts ...RequestOption) (*types.Result, error) {
+	if onEvent == nil {
+		onEvent = func(ProgressEvent) {}
+	}
+
+	onEvent(ProgressEvent{Phase: PhaseQueued})
+	onEvent(ProgressEvent{Phase: PhaseRequesting})
+	result, err := s.Create(ctx, params, opts...)
+	onEvent(ProgressEvent{Phase: PhaseDone})
+
+	return result, err
+}
@@ -0,0 +1,109 @@
+package image
+
+import (
+	"strings"
+
+	"github.com/shamspias/reve-go/internal/validator"
+)
+
+// structuredPrompt breaks a free-text prompt into the clauses most
+// image-generation prompts separate conceptually: what to depict
+// (Subject), how to render it (Style), and what to exclude (Negative).
+type structuredPrompt struct {
+	Subject  string
+	Style    string
+	Negative string
+}
+
+// styleMarkers and negativeMarkers are the phrasing splitPrompt looks
+// for to attribute trailing clauses to Style or Negative; anything
+// before the earliest match stays Subject.
+var (
+	styleMarkers    = []string{"in the style of", "styled as", "style:"}
+	negativeMarkers = []string{"avoid", "without", "do not include", "negative:"}
+)
+
+// splitPrompt heuristically decomposes prompt into a structuredPrompt.
+func splitPrompt(prompt string) structuredPrompt {
+	sp := structuredPrompt{Subject: prompt}
+
+	lower := strings.ToLower(prompt)
+	if idx := firstIndexOfAny(lower, negativeMarkers); idx >= 0 {
+		sp.Negative = strings.TrimSpace(prompt[idx:])
+		sp.Subject = strings.TrimSpace(prompt[:idx])
+		lower = lower[:idx]
+		prompt = prompt[:idx]
+	}
+	if idx := firstIndexOfAny(lower, styleMarkers); idx >= 0 {
+		sp.Style = strings.TrimSpace(prompt[idx:])
+		sp.Subject = strings.TrimSpace(prompt[:idx])
+	}
+	return sp
+}
+
+// firstIndexOfAny returns the earliest index at which any of markers
+// occurs in lower, or -1 if none do.
+func firstIndexOfAny(lower string, markers []string) int {
+	best := -1
+	for _, m := range markers {
+		if idx := strings.Index(lower, m); idx >= 0 && (best == -1 || idx < best) {
+			best = idx
+		}
+	}
+	return best
+}
+
+// composePrompt folds sp back into the single string the API accepts.
+func composePrompt(sp structuredPrompt) string {
+	var b strings.Builder
+	b.WriteString(sp.Subject)
+	if sp.Style != "" {
+		b.WriteString(". ")
+		b.WriteString(sp.Style)
+	}
+	if sp.Negative != "" {
+		b.WriteString(". ")
+		b.WriteString(sp.Negative)
+	}
+	return b.String()
+}
+
+// autoStructurePrompt returns prompt unchanged if it already fits
+// validator.MaxPromptLength. Otherwise it splits prompt into
+// subject/style/negative clauses and shrinks them in order of least
+// essential first -- negative, then style, then subject as a last
+// resort -- until the recomposed prompt fits.
+func autoStructurePrompt(prompt string) string {
+	if validator.PromptLength(prompt) <= validator.MaxPromptLength {
+		return prompt
+	}
+
+	sp := splitPrompt(prompt)
+	for validator.PromptLength(composePrompt(sp)) > validator.MaxPromptLength {
+		switch {
+		case sp.Negative != "":
+			sp.Negative = shrink(sp.Negative)
+		case sp.Style != "":
+			sp.Style = shrink(sp.Style)
+		default:
+			return hardTruncate(sp.Subject, validator.MaxPromptLength)
+		}
+	}
+	return composePrompt(sp)
+}
+
+// shrink drops the last quarter of s, or clears it once too short to
+// usefully shrink further.
+func shrink(s string) string {
+	n := validator.PromptLength(s)
+	if n <= 4 {
+		return ""
+	}
+	return strings.TrimSpace(validator.TruncatePrompt(s, n*3/4))
+}
+
+// hardTruncate cuts s to at most max runes, the last resort once Style
+// and Negative have both been dropped and Subject alone still doesn't fit.
+func hardTruncate(s string, max int) string {
+	return strings.TrimSpace(validator.TruncatePrompt(s, max))
+}
@@ -0,0 +1,56 @@
+package image
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/shamspias/reve-go/internal/validator"
+)
+
+func TestAutoStructurePromptLeavesShortPromptUnchanged(t *testing.T) {
+	const prompt = "a cat in the style of monet, avoid extra limbs"
+	if got := autoStructurePrompt(prompt); got != prompt {
+		t.Errorf("autoStructurePrompt() = %q, want unchanged %q", got, prompt)
+	}
+}
+
+func TestAutoStructurePromptShrinksOverLongPromptWithoutSplittingRunes(t *testing.T) {
+	// Build a prompt whose rune count, not byte count, exceeds the
+	// limit: each "猫" is 3 bytes but 1 rune, so a naive byte-based
+	// length check would shrink this far more aggressively than
+	// necessary, and byte slicing could cut a multi-byte rune in half.
+	subject := strings.Repeat("猫", validator.MaxPromptLength+200)
+	prompt := subject + " in the style of monet. avoid extra limbs"
+
+	got := autoStructurePrompt(prompt)
+
+	if n := validator.PromptLength(got); n > validator.MaxPromptLength {
+		t.Errorf("autoStructurePrompt() result has %d runes, want <= %d", n, validator.MaxPromptLength)
+	}
+	if !utf8.ValidString(got) {
+		t.Error("autoStructurePrompt() result is not valid UTF-8 (a rune was split)")
+	}
+}
+
+func TestHardTruncateIsRuneSafe(t *testing.T) {
+	s := strings.Repeat("猫", 10)
+	got := hardTruncate(s, 4)
+	if n := validator.PromptLength(got); n > 4 {
+		t.Errorf("hardTruncate() has %d runes, want <= 4", n)
+	}
+	if !utf8.ValidString(got) {
+		t.Error("hardTruncate() result is not valid UTF-8 (a rune was split)")
+	}
+}
+
+func TestShrinkIsRuneSafe(t *testing.T) {
+	s := strings.Repeat("猫", 20)
+	got := shrink(s)
+	if !utf8.ValidString(got) {
+		t.Error("shrink() result is not valid UTF-8 (a rune was split)")
+	}
+	if validator.PromptLength(got) >= validator.PromptLength(s) {
+		t.Errorf("shrink() did not shorten s: got %d runes, want fewer than %d", validator.PromptLength(got), validator.PromptLength(s))
+	}
+}
@@ -0,0 +1,81 @@
+package image
+
+import "context"
+
+// QuotaManager partitions a client's credit budget across named shares
+// (e.g. "interactive" vs "background-jobs"), so background work can't
+// starve user-facing traffic. See reve.WithQuotaManager and package
+// quota for a bundled implementation.
+type QuotaManager interface {
+	// Reserve checks whether partition has room for an estimated spend
+	// of credits, and if so counts credits against partition's usage
+	// immediately, so concurrent callers see the reservation right
+	// away instead of racing each other past limit before any of them
+	// reports actual usage. Returns an error if there isn't room.
+	// Called before a request is sent.
+	Reserve(ctx context.Context, partition string, credits int) error
+
+	// Release gives back a reservation made by Reserve for a call that
+	// didn't complete (e.g. the request failed before a response came
+	// back), so it doesn't permanently inflate partition's usage.
+	Release(partition string, credits int)
+
+	// Record reports a partition's actual credit spend after a request
+	// completes, so Reserve's estimate is reconciled against reality.
+	// Callers release the estimate via Release before calling Record
+	// with the real cost.
+	Record(partition string, credits int)
+}
+
+// defaultPartition is used for requests that don't set a Partition via
+// WithPartition.
+const defaultPartition = "default"
+
+// reserveQuota consults s.quota, if any, for an estimated spend of
+// credits against partition (defaultPartition when empty).
+func (s *Service) reserveQuota(ctx context.Context, partition string, credits int) error {
+	if s.quota == nil {
+		return nil
+	}
+	if partition == "" {
+		partition = defaultPartition
+	}
+	err := s.quota.Reserve(ctx, partition, credits)
+	if err != nil {
+		s.emitEvent("quota_exceeded", "WARN", map[string]string{"partition": partition})
+	}
+	return err
+}
+
+// releaseQuota gives back a reservation made by reserveQuota for a call
+// that didn't complete, so it doesn't permanently inflate partition's
+// usage.
+func (s *Service) releaseQuota(partition string, credits int) {
+	if s.quota == nil {
+		return
+	}
+	if partition == "" {
+		partition = defaultPartition
+	}
+	s.quota.Release(partition, credits)
+}
+
+// recordQuota reports actual spend to s.quota, if any.
+func (s *Service) recordQuota(partition string, credits int) {
+	if s.quota == nil {
+		return
+	}
+	if partition == "" {
+		partition = defaultPartition
+	}
+	s.quota.Record(partition, credits)
+}
+
+// recordUsage reconciles a completed call's reserved estimate against
+// its actual spend -- releasing the estimate and recording the real
+// cost -- and updates the balance returned by Service.CreditsRemaining.
+func (s *Service) recordUsage(partition string, estimated, used, remaining int) {
+	s.releaseQuota(partition, estimated)
+	s.recordQuota(partition, used)
+	s.credits.record(remaining)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/shamspias/reve-go/internal/transport"
 	"github.com/shamspias/reve-go/internal/validator"
@@ -36,6 +37,13 @@ type RemixParams struct {
 	// Default: 1
 	TestTimeScaling float64 `json:"test_time_scaling,omitempty"`
 
+	// OutputFormat requests a specific binary format via the Accept header.
+	// When set to an image MIME type (e.g. types.FormatWebP), Remix
+	// transparently fetches the image through the raw path and base64
+	// encodes it into Result.Image, so callers keep using the unified
+	// *types.Result return type regardless of wire format.
+	OutputFormat types.OutputFormat `json:"-"`
+
 	// Breadcrumb is an optional tracking ID.
 	Breadcrumb string `json:"-"`
 }
@@ -73,30 +81,85 @@ func (p *RemixParams) Validate() error {
 //		Prompt: fmt.Sprintf("Apply style from %s to %s", types.Ref(0), types.Ref(1)),
 //		ReferenceImages: []string{style.Base64(), content.Base64()},
 //	})
-func (s *Service) Remix(ctx context.Context, params *RemixParams) (*types.Result, error) {
+func (s *Service) Remix(ctx context.Context, params *RemixParams, opts ...RequestOption) (*types.Result, error) {
 	if params == nil {
 		return nil, validator.ErrEmptyPrompt
 	}
+	for i, img := range params.ReferenceImages {
+		params.ReferenceImages[i] = s.downscaleReferenceImage(img)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
+	s.emitLintWarnings(params.Lint())
+	params.Prompt = s.scrubPrompt(params.Prompt)
+	if err := s.screenPrompt(params.Prompt); err != nil {
+		return nil, err
+	}
+	forceFast, err := s.checkFlags(ctx, OpRemix)
+	if err != nil {
+		return nil, err
+	}
+	applyFastModel(&params.Version, forceFast)
+	if err := s.checkCapabilities(ctx, params.Version, params.AspectRatio, 0, params.Postprocess); err != nil {
+		return nil, err
+	}
+
+	if isBinaryFormat(params.OutputFormat) {
+		key := CacheKey(params.Prompt+"\x00"+strings.Join(params.ReferenceImages, "\x00"), 0, params.Version, params.AspectRatio)
+		return s.withCacheAndDedup(ctx, key, func() (*types.Result, error) {
+			return s.withFallback(ctx, params, func() (*types.Result, error) {
+				raw, err := s.RemixRaw(ctx, params, params.OutputFormat, opts...)
+				if err != nil {
+					return nil, err
+				}
+				return rawToResult(raw), nil
+			})
+		})
+	}
 
-	resp, err := s.transport.Do(ctx, &transport.Request{
+	req := &transport.Request{
 		Method:     http.MethodPost,
 		Path:       "/v1/image/remix",
 		Body:       params,
 		Breadcrumb: params.Breadcrumb,
-	})
-	if err != nil {
+	}
+	applyRequestOptions(req, opts)
+	if err := s.attachReferenceImages(req, "reference_images", params.ReferenceImages); err != nil {
 		return nil, err
 	}
 
-	var result types.Result
-	if err := json.Unmarshal(resp.Body, &result); err != nil {
+	estimate := EstimateRemix(params.Version == types.VersionRemixFast20251030, params.TestTimeScaling, params.Postprocess).TotalCredits
+	if err := s.reserveQuota(ctx, req.Partition, estimate); err != nil {
 		return nil, err
 	}
 
-	return &result, nil
+	key := CacheKey(params.Prompt+"\x00"+strings.Join(params.ReferenceImages, "\x00"), 0, params.Version, params.AspectRatio)
+	return s.withCacheAndDedup(ctx, key, func() (*types.Result, error) {
+		return s.withFallback(ctx, params, func() (*types.Result, error) {
+			resp, err := s.transport.Do(ctx, req)
+			if err != nil {
+				s.emitTransportError(err)
+				s.releaseQuota(req.Partition, estimate)
+				return nil, err
+			}
+
+			var result types.Result
+			if err := json.Unmarshal(resp.Body, &result); err != nil {
+				s.releaseQuota(req.Partition, estimate)
+				return nil, err
+			}
+			s.recordUsage(req.Partition, estimate, result.CreditsUsed, result.CreditsRemaining)
+			if result.ContentViolation {
+				s.emitEvent("content_violation", "WARN", map[string]string{"request_id": result.RequestID})
+			}
+			result.Region = s.region
+			result.AttemptCount = resp.Attempts
+			result.Latency = resp.Elapsed
+
+			return &result, nil
+		})
+	})
 }
 
 // RemixRaw combines images and returns raw bytes.
@@ -109,28 +172,61 @@ func (s *Service) Remix(ctx context.Context, params *RemixParams) (*types.Result
 //		ReferenceImages: images,
 //		Version:         types.VersionLatestFast,
 //	}, types.FormatWebP)
-func (s *Service) RemixRaw(ctx context.Context, params *RemixParams, format types.OutputFormat) (*types.RawResult, error) {
+func (s *Service) RemixRaw(ctx context.Context, params *RemixParams, format types.OutputFormat, opts ...RequestOption) (*types.RawResult, error) {
 	if params == nil {
 		return nil, validator.ErrEmptyPrompt
 	}
+	for i, img := range params.ReferenceImages {
+		params.ReferenceImages[i] = s.downscaleReferenceImage(img)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
+	s.emitLintWarnings(params.Lint())
+	params.Prompt = s.scrubPrompt(params.Prompt)
+	if err := s.screenPrompt(params.Prompt); err != nil {
+		return nil, err
+	}
+	forceFast, err := s.checkFlags(ctx, OpRemix)
+	if err != nil {
+		return nil, err
+	}
+	applyFastModel(&params.Version, forceFast)
+	if err := s.checkCapabilities(ctx, params.Version, params.AspectRatio, 0, params.Postprocess); err != nil {
+		return nil, err
+	}
 
 	if format == "" || format == types.FormatJSON {
 		format = types.FormatPNG
 	}
 
-	resp, err := s.transport.DoRaw(ctx, &transport.Request{
+	req := &transport.Request{
 		Method:     http.MethodPost,
 		Path:       "/v1/image/remix",
 		Body:       params,
 		Accept:     string(format),
 		Breadcrumb: params.Breadcrumb,
-	})
+	}
+	applyRequestOptions(req, opts)
+	if err := s.attachReferenceImages(req, "reference_images", params.ReferenceImages); err != nil {
+		return nil, err
+	}
+
+	estimate := EstimateRemix(params.Version == types.VersionRemixFast20251030, params.TestTimeScaling, params.Postprocess).TotalCredits
+	if err := s.reserveQuota(ctx, req.Partition, estimate); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.transport.DoRaw(ctx, req)
 	if err != nil {
+		s.emitTransportError(err)
+		s.releaseQuota(req.Partition, estimate)
 		return nil, err
 	}
+	s.recordUsage(req.Partition, estimate, resp.CreditsUsed, resp.CreditsRemaining)
+	if resp.ContentViolation {
+		s.emitEvent("content_violation", "WARN", map[string]string{"request_id": resp.RequestID})
+	}
 
 	return &types.RawResult{
 		Data:             resp.Data,
@@ -140,5 +236,11 @@ func (s *Service) RemixRaw(ctx context.Context, params *RemixParams, format type
 		RequestID:        resp.RequestID,
 		CreditsUsed:      resp.CreditsUsed,
 		CreditsRemaining: resp.CreditsRemaining,
+		ETag:             resp.ETag,
+		NotModified:      resp.NotModified,
+		Seed:             resp.Seed,
+		Region:           s.region,
+		AttemptCount:     resp.Attempts,
+		Latency:          resp.Elapsed,
 	}, nil
 }
@@ -0,0 +1,80 @@
+package image
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shamspias/reve-go/types"
+)
+
+// remixPlaceholder matches a {{name}} reference placeholder in a
+// RemixBuilder template.
+var remixPlaceholder = regexp.MustCompile(`\{\{(\w+)\}\}`)
+
+// RemixBuilder assembles RemixParams from named reference images instead
+// of hand-counted <img>N</img> indices, so reordering or adding a ref
+// doesn't silently shift which image a tag points at.
+//
+// Example:
+//
+//	params, err := image.NewRemixBuilder().
+//		AddRef("style", styleImg).
+//		AddRef("subject", subjImg).
+//		Prompt("Apply {{style}} to {{subject}}").
+//		Build()
+type RemixBuilder struct {
+	names    []string
+	images   []string
+	template string
+}
+
+// NewRemixBuilder creates an empty RemixBuilder.
+func NewRemixBuilder() *RemixBuilder {
+	return &RemixBuilder{}
+}
+
+// AddRef registers img under name, so Prompt's template can refer to it
+// as {{name}} instead of an index. Names must be unique.
+func (b *RemixBuilder) AddRef(name string, img *types.Image) *RemixBuilder {
+	b.names = append(b.names, name)
+	b.images = append(b.images, img.Base64())
+	return b
+}
+
+// Prompt sets the template string, with {{name}} placeholders for each
+// name passed to AddRef. Build resolves each placeholder to the
+// matching <img>N</img> tag.
+func (b *RemixBuilder) Prompt(template string) *RemixBuilder {
+	b.template = template
+	return b
+}
+
+// Build resolves the template's {{name}} placeholders against the
+// registered refs and returns the assembled RemixParams. It returns an
+// error if the template references a name no AddRef call registered.
+func (b *RemixBuilder) Build() (*RemixParams, error) {
+	prompt := b.template
+	for _, match := range remixPlaceholder.FindAllStringSubmatch(b.template, -1) {
+		placeholder, name := match[0], match[1]
+		index := indexOfRef(b.names, name)
+		if index < 0 {
+			return nil, fmt.Errorf("reve: remix builder: prompt references %q, but no AddRef(%q, ...) was called", name, name)
+		}
+		prompt = strings.ReplaceAll(prompt, placeholder, types.Ref(index))
+	}
+
+	return &RemixParams{
+		Prompt:          prompt,
+		ReferenceImages: append([]string{}, b.images...),
+	}, nil
+}
+
+func indexOfRef(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
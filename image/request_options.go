@@ -0,0 +1,103 @@
+package image
+
+import (
+	"time"
+
+	"github.com/shamspias/reve-go/internal/transport"
+)
+
+// RequestOption customizes a single Create/Edit/Remix call, overriding the
+// client's defaults without constructing a second client.
+type RequestOption func(*transport.Request)
+
+// WithRequestTimeout bounds this request's whole call, retries included.
+// The client's default timeout still bounds each attempt, so d can
+// shorten a request but cannot extend an attempt past that default.
+//
+// Example:
+//
+//	result, err := client.Images.Create(ctx, params,
+//		image.WithRequestTimeout(30*time.Second),
+//	)
+func WithRequestTimeout(d time.Duration) RequestOption {
+	return func(r *transport.Request) {
+		r.Timeout = d
+	}
+}
+
+// WithRequestRetry overrides the client's default retry count for this
+// request only.
+//
+// Example:
+//
+//	result, err := client.Images.Create(ctx, params, image.WithRequestRetry(0))
+func WithRequestRetry(maxRetries int) RequestOption {
+	return func(r *transport.Request) {
+		r.MaxRetries = &maxRetries
+	}
+}
+
+// WithRequestHeader sets an additional header on this request only.
+//
+// Example:
+//
+//	result, err := client.Images.Create(ctx, params,
+//		image.WithRequestHeader("X-Idempotency-Key", key),
+//	)
+func WithRequestHeader(key, value string) RequestOption {
+	return func(r *transport.Request) {
+		if r.Headers == nil {
+			r.Headers = make(map[string]string)
+		}
+		r.Headers[key] = value
+	}
+}
+
+// WithIdempotencyKey marks this request as safe to retry or hedge blindly,
+// since the API will treat repeated deliveries of the same key as one
+// logical request. Required to opt a call into client-level hedging.
+//
+// Example:
+//
+//	result, err := client.Images.Edit(ctx, params, image.WithIdempotencyKey(key))
+func WithIdempotencyKey(key string) RequestOption {
+	return func(r *transport.Request) {
+		r.IdempotencyKey = key
+	}
+}
+
+// WithIfNoneMatch sets the If-None-Match header to a previously seen ETag,
+// letting the server answer 304 Not Modified instead of re-sending a
+// result the caller already has cached. Only meaningful on the raw
+// methods (CreateRaw, EditRaw, RemixRaw), whose RawResult.NotModified
+// reports whether the cache hit.
+//
+// Example:
+//
+//	raw, err := client.Images.CreateRaw(ctx, params, types.FormatPNG,
+//		image.WithIfNoneMatch(cached.ETag),
+//	)
+func WithIfNoneMatch(etag string) RequestOption {
+	return func(r *transport.Request) {
+		r.IfNoneMatch = etag
+	}
+}
+
+// WithPartition tags this request with a named quota partition, so a
+// QuotaManager installed via reve.WithQuotaManager draws its credit
+// budget from that partition's share instead of the default one.
+//
+// Example:
+//
+//	result, err := client.Images.Create(ctx, params, image.WithPartition("background-jobs"))
+func WithPartition(name string) RequestOption {
+	return func(r *transport.Request) {
+		r.Partition = name
+	}
+}
+
+func applyRequestOptions(req *transport.Request, opts []RequestOption) {
+	for _, opt := range opts {
+		opt(req)
+	}
+}
@@ -0,0 +1,17 @@
+package image
+
+// PromptScrubber redacts sensitive text (names, emails, and similar
+// PII) from a prompt or edit instruction before it is used as a cache
+// key or included in diagnostics, letting compliance teams enforce
+// redaction without touching call sites. It runs before ContentFilter,
+// so FlagProvider, ContentFilter, caching, and the support bundle
+// transcript all see the scrubbed text.
+type PromptScrubber func(prompt string) string
+
+// scrubPrompt runs the configured PromptScrubber, if any, against prompt.
+func (s *Service) scrubPrompt(prompt string) string {
+	if s.promptScrubber == nil {
+		return prompt
+	}
+	return s.promptScrubber(prompt)
+}
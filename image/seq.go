@@ -0,0 +1,97 @@
+package image
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/shamspias/reve-go/types"
+)
+
+// GenerateSeq consumes params and calls Create for each one using up to
+// concurrency workers, returning an iter.Seq2 a caller can range over
+// as results complete, instead of collecting a []BatchResult up front.
+// It's meant for unbounded or long-running streams of params where
+// BatchCreate's "wait for every request" shape doesn't fit.
+//
+// params may be closed to end the stream; cancelling ctx, or breaking
+// out of the range loop early, stops in-flight workers and stops
+// reading params, without starting further requests.
+//
+// Example:
+//
+//	params := make(chan *image.CreateParams)
+//	go func() {
+//		defer close(params)
+//		for _, prompt := range prompts {
+//			params <- &image.CreateParams{Prompt: prompt}
+//		}
+//	}()
+//
+//	for result, err := range client.Images.GenerateSeq(ctx, params, 4) {
+//		if err != nil {
+//			log.Printf("generate failed: %v", err)
+//			continue
+//		}
+//		result.SaveTo(fmt.Sprintf("out_%s.png", result.RequestID))
+//	}
+func (s *Service) GenerateSeq(ctx context.Context, params <-chan *CreateParams, concurrency int) iter.Seq2[*types.Result, error] {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return func(yield func(*types.Result, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type pair struct {
+			result *types.Result
+			err    error
+		}
+		out := make(chan pair)
+		sem := make(chan struct{}, concurrency)
+
+		var wg sync.WaitGroup
+		go func() {
+		feed:
+			for {
+				select {
+				case p, ok := <-params:
+					if !ok {
+						break feed
+					}
+					select {
+					case sem <- struct{}{}:
+					case <-ctx.Done():
+						break feed
+					}
+					wg.Add(1)
+					go func(p *CreateParams) {
+						defer wg.Done()
+						defer func() { <-sem }()
+						result, err := s.Create(ctx, p)
+						select {
+						case out <- pair{result, err}:
+						case <-ctx.Done():
+						}
+					}(p)
+				case <-ctx.Done():
+					break feed
+				}
+			}
+			wg.Wait()
+			close(out)
+		}()
+
+		for p := range out {
+			if !yield(p.result, p.err) {
+				cancel()
+				for range out {
+					// drain so the feeding goroutines above don't block
+					// forever sending to out after cancel.
+				}
+				return
+			}
+		}
+	}
+}
@@ -22,14 +22,140 @@ package image
 
 import (
 	"github.com/shamspias/reve-go/internal/transport"
+	"github.com/shamspias/reve-go/internal/validator"
 )
 
 // Service handles image operations.
 type Service struct {
-	transport *transport.Client
+	transport      *transport.Client
+	contentFilter  ContentFilter
+	fallback       Fallback
+	cache          Cache
+	flags          FlagProvider
+	singleflight   *singleflightGroup
+	quota          QuotaManager
+	promptScrubber PromptScrubber
+	region         string
+	capabilities   CapabilityProvider
+	credits        creditsSnapshot
+	events         EventSink
+
+	// autoDownscaleMegapixels is the resolution SetAutoDownscale
+	// downscales oversized PNG/JPEG reference images to. Zero disables
+	// auto-downscaling; Validate then rejects an oversized image instead.
+	autoDownscaleMegapixels float64
 }
 
 // NewService creates a new image service.
 func NewService(t *transport.Client) *Service {
 	return &Service{transport: t}
 }
+
+// SetContentFilter installs a ContentFilter run against every prompt
+// before it is sent to the API. Used by reve.WithContentFilter.
+func (s *Service) SetContentFilter(filter ContentFilter) {
+	s.contentFilter = filter
+}
+
+// SetFallback installs a Fallback run when Create, Edit, or Remix fail
+// after exhausting retries. Used by reve.WithFallback.
+func (s *Service) SetFallback(fallback Fallback) {
+	s.fallback = fallback
+}
+
+// SetCache installs a Cache consulted before, and populated after, every
+// Create, Edit, or Remix call. Used by reve.WithCache.
+func (s *Service) SetCache(cache Cache) {
+	s.cache = cache
+}
+
+// SetFlags installs a FlagProvider consulted before every Create, Edit,
+// and Remix call. Used by reve.WithFeatureFlags.
+func (s *Service) SetFlags(flags FlagProvider) {
+	s.flags = flags
+}
+
+// SetSingleflight enables or disables in-flight request coalescing:
+// when enabled, concurrent Create, Edit, or Remix calls with identical
+// params share a single API call and result. Used by
+// reve.WithSingleflight.
+func (s *Service) SetSingleflight(enabled bool) {
+	if enabled {
+		s.singleflight = &singleflightGroup{}
+		return
+	}
+	s.singleflight = nil
+}
+
+// SetQuotaManager installs a QuotaManager consulted before, and
+// reported to after, every Create, Edit, and Remix call. Used by
+// reve.WithQuotaManager.
+func (s *Service) SetQuotaManager(quota QuotaManager) {
+	s.quota = quota
+}
+
+// SetPromptScrubber installs a PromptScrubber applied to every prompt
+// and edit instruction before it's screened, cached, or diagnosed.
+// Used by reve.WithPromptScrubber.
+func (s *Service) SetPromptScrubber(scrubber PromptScrubber) {
+	s.promptScrubber = scrubber
+}
+
+// SetRegion records the data-residency region results are annotated
+// with (see types.Result.Region). Used by reve.WithRegion.
+func (s *Service) SetRegion(region string) {
+	s.region = region
+}
+
+// SetCapabilityProvider installs a CapabilityProvider consulted before
+// every Create, Edit, and Remix call, to validate params against what
+// the requested model version actually supports. Used by
+// reve.WithCapabilityProvider.
+func (s *Service) SetCapabilityProvider(provider CapabilityProvider) {
+	s.capabilities = provider
+}
+
+// SetEventSink installs an EventSink that receives a content_violation
+// event after every Create, Edit, or Remix response that reports one, a
+// quota_exceeded event whenever a QuotaManager rejects a reservation,
+// a lint_warning event for each Lint warning on the params of a Create,
+// Edit, or Remix call, and a circuit_open event whenever the circuit
+// breaker fails a call.
+// Used by reve.WithEventSink.
+func (s *Service) SetEventSink(sink EventSink) {
+	s.events = sink
+}
+
+// SetAutoDownscale enables automatically downscaling an oversized PNG
+// or JPEG reference image to maxMegapixels before validating it,
+// instead of Validate failing it with ErrImageResolutionTooLarge. Pass
+// 0 to use validator.MaxInputMegapixels, the same default Validate
+// enforces. WebP references are unaffected: the standard library has no
+// WebP encoder to write a downscaled result back out, so an oversized
+// WebP reference still fails Validate. Used by reve.WithAutoDownscale.
+func (s *Service) SetAutoDownscale(maxMegapixels float64) {
+	if maxMegapixels <= 0 {
+		maxMegapixels = validator.MaxInputMegapixels
+	}
+	s.autoDownscaleMegapixels = maxMegapixels
+}
+
+// Reload atomically swaps the underlying transport's retry policy,
+// circuit breaker, hedging, deadline margin, and concurrency cap for the
+// settings in cfg, without affecting calls already in progress. See
+// transport.Client.Reload.
+//
+// Reload does not touch the QuotaManager installed by SetQuotaManager;
+// call that again directly to change budgets, subject to its existing
+// caveat of not being safe to call concurrently with in-flight requests.
+func (s *Service) Reload(cfg *transport.ReloadConfig) {
+	s.transport.Reload(cfg)
+}
+
+// screenPrompt runs the configured ContentFilter, if any, against prompt.
+func (s *Service) screenPrompt(prompt string) error {
+	if s.contentFilter == nil {
+		return nil
+	}
+	return s.contentFilter(prompt)
+}
@@ -0,0 +1,118 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shamspias/reve-go/types"
+)
+
+// ErrNoHistory is returned by Undo when there's no image before the
+// current one to undo to.
+var ErrNoHistory = errors.New("image: session has no prior image to undo to")
+
+// Session tracks one hero-image refinement session: the current image,
+// the sequence of edit instructions applied to reach it, credits spent,
+// and enough history to undo back to an earlier step. Models the
+// interactive refinement UX most products build on top of Create and
+// Edit. The zero value is not ready to use; create one with NewSession.
+type Session struct {
+	svc *Service
+
+	// history[0] is the initial Create result; history[i] is the result
+	// of applying instructions[i-1] to history[i-1].
+	history      []*types.Result
+	instructions []string
+	creditsSpent int
+}
+
+// NewSession starts a Session by calling Create with initial.
+func NewSession(ctx context.Context, svc *Service, initial *CreateParams, opts ...RequestOption) (*Session, error) {
+	result, err := svc.Create(ctx, initial, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{
+		svc:          svc,
+		history:      []*types.Result{result},
+		creditsSpent: result.CreditsUsed,
+	}, nil
+}
+
+// Current returns the session's current image.
+func (sess *Session) Current() *types.Result {
+	return sess.history[len(sess.history)-1]
+}
+
+// CreditsSpent returns the total credits spent across every Create and
+// Edit call the session has made, including images later undone.
+func (sess *Session) CreditsSpent() int {
+	return sess.creditsSpent
+}
+
+// Instructions returns the edit instructions applied to reach the
+// current image, oldest first, reflecting any Undo calls.
+func (sess *Session) Instructions() []string {
+	return append([]string(nil), sess.instructions...)
+}
+
+// Edit applies instruction to the current image via Edit, pushing the
+// result onto the session's undo history and replacing Current.
+func (sess *Session) Edit(ctx context.Context, instruction string, opts ...RequestOption) (*types.Result, error) {
+	current := sess.Current()
+	result, err := sess.svc.Edit(ctx, &EditParams{
+		Instruction:    instruction,
+		ReferenceImage: current.Image,
+		Version:        types.ModelVersion(current.Version),
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	sess.creditsSpent += result.CreditsUsed
+	sess.history = append(sess.history, result)
+	sess.instructions = append(sess.instructions, instruction)
+	return result, nil
+}
+
+// BranchFrom creates a new Session starting from the image at step (0
+// is the initial Create result), so you can try a different direction
+// without losing the original Session's Undo history.
+//
+// The Reve API has no way to reference a previous generation by ID or
+// breadcrumb -- Edit always sends the full reference image bytes, not
+// a pointer to an earlier result -- so this only branches the Session's
+// local bookkeeping. The branch's next Edit call still re-uploads
+// Current().Image in full, and CreditsSpent on the branch reflects what
+// was already spent reaching step, not a fresh start.
+func (sess *Session) BranchFrom(step int) (*Session, error) {
+	if step < 0 || step >= len(sess.history) {
+		return nil, fmt.Errorf("image: branch step %d out of range [0,%d)", step, len(sess.history))
+	}
+
+	var spent int
+	for _, r := range sess.history[:step+1] {
+		spent += r.CreditsUsed
+	}
+
+	return &Session{
+		svc:          sess.svc,
+		history:      append([]*types.Result(nil), sess.history[:step+1]...),
+		instructions: append([]string(nil), sess.instructions[:step]...),
+		creditsSpent: spent,
+	}, nil
+}
+
+// Undo reverts Current to the image before the most recent Edit.
+// Credits already spent producing the undone image aren't refunded
+// (see CreditsSpent). Returns ErrNoHistory if the session is still on
+// its initial Create result.
+func (sess *Session) Undo() (*types.Result, error) {
+	if len(sess.history) <= 1 {
+		return nil, ErrNoHistory
+	}
+	sess.history = sess.history[:len(sess.history)-1]
+	sess.instructions = sess.instructions[:len(sess.instructions)-1]
+	return sess.Current(), nil
+}
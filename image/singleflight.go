@@ -0,0 +1,71 @@
+package image
+
+import (
+	"context"
+	"sync"
+
+	"github.com/shamspias/reve-go/types"
+)
+
+// singleflightGroup coalesces concurrent calls sharing the same key into
+// one in-flight call, so N goroutines requesting the exact same params
+// at the same time spend credits on a single API call. See
+// reve.WithSingleflight.
+type singleflightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*singleflightCall
+}
+
+type singleflightCall struct {
+	wg     sync.WaitGroup
+	result *types.Result
+	err    error
+}
+
+// do runs fn for key, or waits for and shares the result of an
+// already-in-flight call for the same key.
+func (g *singleflightGroup) do(key string, fn func() (*types.Result, error)) (*types.Result, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*singleflightCall)
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.result, c.err
+	}
+
+	c := &singleflightCall{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.result, c.err = fn()
+	c.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return c.result, c.err
+}
+
+// coalesced runs fn through s.singleflight, if enabled, so concurrent
+// calls sharing key wait for and share one in-flight result instead of
+// each making their own API call.
+func (s *Service) coalesced(key string, fn func() (*types.Result, error)) (*types.Result, error) {
+	if s.singleflight == nil {
+		return fn()
+	}
+	return s.singleflight.do(key, fn)
+}
+
+// withCacheAndDedup wraps fn with both request deduplication and
+// response caching, in that order: concurrent identical requests
+// coalesce into one call, and that call's result populates s.cache for
+// later callers.
+func (s *Service) withCacheAndDedup(ctx context.Context, key string, fn func() (*types.Result, error)) (*types.Result, error) {
+	return s.coalesced(key, func() (*types.Result, error) {
+		return s.cached(ctx, key, fn)
+	})
+}
@@ -0,0 +1,91 @@
+package image
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shamspias/reve-go/types"
+)
+
+func TestSingleflightGroupCoalescesConcurrentCalls(t *testing.T) {
+	var g singleflightGroup
+	var calls int32
+
+	fn := func() (*types.Result, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return &types.Result{RequestID: "shared"}, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*types.Result, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r, err := g.do("key", fn)
+			if err != nil {
+				t.Errorf("do() error = %v", err)
+				return
+			}
+			results[i] = r
+		}(i)
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("calls = %d, want 1 (concurrent calls should coalesce)", got)
+	}
+	for i, r := range results {
+		if r == nil || r.RequestID != "shared" {
+			t.Errorf("results[%d] = %v, want the shared result", i, r)
+		}
+	}
+}
+
+func TestSingleflightGroupSeparateKeysDontCoalesce(t *testing.T) {
+	var g singleflightGroup
+	var calls int32
+
+	fn := func() (*types.Result, error) {
+		atomic.AddInt32(&calls, 1)
+		return &types.Result{}, nil
+	}
+
+	var wg sync.WaitGroup
+	for _, key := range []string{"a", "b"} {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			_, _ = g.do(key, fn)
+		}(key)
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("calls = %d, want 2 (different keys should not coalesce)", got)
+	}
+}
+
+func TestSingleflightGroupSequentialCallsDontCoalesce(t *testing.T) {
+	var g singleflightGroup
+	var calls int32
+
+	fn := func() (*types.Result, error) {
+		atomic.AddInt32(&calls, 1)
+		return &types.Result{}, nil
+	}
+
+	if _, err := g.do("key", fn); err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+	if _, err := g.do("key", fn); err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("calls = %d, want 2 (a finished call shouldn't coalesce with a later one)", got)
+	}
+}
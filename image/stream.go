@@ -0,0 +1,78 @@
+package image
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/shamspias/reve-go/internal/transport"
+	"github.com/shamspias/reve-go/internal/validator"
+	"github.com/shamspias/reve-go/types"
+)
+
+// StreamMeta carries response metadata available from headers before the
+// image body has been read.
+type StreamMeta struct {
+	ContentType      string
+	Version          string
+	ContentViolation bool
+	RequestID        string
+	CreditsUsed      int
+	CreditsRemaining int
+}
+
+// CreateStream generates an image and returns the response body unread,
+// so web handlers can io.Copy the result directly to a client with no
+// intermediate buffering. The caller owns the returned io.ReadCloser and
+// must Close it.
+//
+// Example:
+//
+//	body, meta, err := client.Images.CreateStream(ctx, &image.CreateParams{
+//		Prompt: "A sunset over the ocean",
+//	}, types.FormatPNG)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer body.Close()
+//	w.Header().Set("Content-Type", meta.ContentType)
+//	io.Copy(w, body)
+func (s *Service) CreateStream(ctx context.Context, params *CreateParams, format types.OutputFormat, opts ...RequestOption) (io.ReadCloser, *StreamMeta, error) {
+	if params == nil {
+		return nil, nil, validator.ErrEmptyPrompt
+	}
+	if err := params.Validate(); err != nil {
+		return nil, nil, err
+	}
+	params.Prompt = s.scrubPrompt(params.Prompt)
+	if err := s.screenPrompt(params.Prompt); err != nil {
+		return nil, nil, err
+	}
+
+	if format == "" || format == types.FormatJSON {
+		format = types.FormatPNG
+	}
+
+	req := &transport.Request{
+		Method:     http.MethodPost,
+		Path:       "/v1/image/create",
+		Body:       params,
+		Accept:     string(format),
+		Breadcrumb: params.Breadcrumb,
+	}
+	applyRequestOptions(req, opts)
+
+	body, meta, err := s.transport.DoStream(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return body, &StreamMeta{
+		ContentType:      meta.ContentType,
+		Version:          meta.Version,
+		ContentViolation: meta.ContentViolation,
+		RequestID:        meta.RequestID,
+		CreditsUsed:      meta.CreditsUsed,
+		CreditsRemaining: meta.CreditsRemaining,
+	}, nil
+}
@@ -0,0 +1,102 @@
+// Package imagecache provides a bounded, in-process cache for decoded
+// images, so code that decodes the same encoded bytes repeatedly -- a
+// logo composited onto every image in a batch, a thumbnail grid built
+// from a results directory -- only pays the decode cost once per
+// distinct image.
+//
+// Reve itself has no "gallery" or "report" package today for this to
+// plug into directly; the nearest real, existing repeat-decode site is
+// brand.BrandKit.Overlay, which re-decodes its Logo on every call. Cache
+// is used there. Other decode-heavy call sites can adopt it the same
+// way: look up by the encoded bytes, decode and store on a miss.
+package imagecache
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	stdimage "image"
+	"sync"
+)
+
+// Cache is an in-process store of decoded images keyed by a hash of
+// their encoded bytes. It evicts the least recently used entry once it
+// holds more than its capacity. The zero value is not usable; see New.
+type Cache struct {
+	capacity int
+
+	mu      sync.Mutex
+	ll      *list.List // front = most recently used
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key string
+	img stdimage.Image
+}
+
+// New creates a Cache holding at most capacity decoded images. A
+// non-positive capacity means unbounded.
+func New(capacity int) *Cache {
+	return &Cache{
+		capacity: capacity,
+		ll:       list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Key returns the cache key for data: a hex-encoded sha256 digest of
+// the encoded bytes, following the same hashing convention as
+// image.CacheKey.
+func Key(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Decode returns the decoded form of data, consulting the cache first
+// and populating it with a fresh stdimage.Decode on a miss.
+func (c *Cache) Decode(data []byte) (stdimage.Image, error) {
+	key := Key(data)
+
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.ll.MoveToFront(elem)
+		img := elem.Value.(*cacheEntry).img
+		c.mu.Unlock()
+		return img, nil
+	}
+	c.mu.Unlock()
+
+	img, _, err := stdimage.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.ll.MoveToFront(elem)
+		return elem.Value.(*cacheEntry).img, nil
+	}
+
+	elem := c.ll.PushFront(&cacheEntry{key: key, img: img})
+	c.entries[key] = elem
+
+	if c.capacity > 0 && c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.entries, oldest.Value.(*cacheEntry).key)
+		}
+	}
+	return img, nil
+}
+
+// Len returns the number of images currently cached.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
@@ -0,0 +1,53 @@
+package stream
+
+import "context"
+
+// FuncSource adapts a plain function to Source, for brokers whose client
+// already exposes a delivery channel and needs no further setup beyond
+// wrapping each delivery as a Message — AMQP/RabbitMQ via amqp091-go
+// chief among them.
+//
+// Example (wrapping an amqp091-go Channel.Consume):
+//
+//	deliveries, err := ch.Consume("jobs", "", false, false, false, false, nil)
+//	if err != nil {
+//		return err
+//	}
+//	source := stream.FuncSource(func(ctx context.Context) (<-chan stream.Message, error) {
+//		out := make(chan stream.Message)
+//		go func() {
+//			defer close(out)
+//			for {
+//				select {
+//				case d, ok := <-deliveries:
+//					if !ok {
+//						return
+//					}
+//					out <- stream.Message{
+//						Data: d.Body,
+//						Ack:  func() error { return d.Ack(false) },
+//					}
+//				case <-ctx.Done():
+//					return
+//				}
+//			}
+//		}()
+//		return out, nil
+//	})
+//	err = stream.Run(ctx, client.Images, source, sink, nil)
+type FuncSource func(ctx context.Context) (<-chan Message, error)
+
+// Messages calls f.
+func (f FuncSource) Messages(ctx context.Context) (<-chan Message, error) {
+	return f(ctx)
+}
+
+// FuncSink adapts a plain publish function to Sink, for a broker client
+// whose publish call (e.g. amqp091-go Channel.PublishWithContext) can be
+// wrapped in a single closure without any extra state.
+type FuncSink func(ctx context.Context, data []byte) error
+
+// Publish calls f.
+func (f FuncSink) Publish(ctx context.Context, data []byte) error {
+	return f(ctx, data)
+}
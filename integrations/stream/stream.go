@@ -0,0 +1,176 @@
+// Package stream wires event-driven message brokers up to the image
+// generation batch subsystem, for pipelines where generation requests
+// arrive continuously off a Kafka topic or NATS subject rather than as
+// a fixed slice or file.
+//
+// The package stays free of any particular broker client: Source and
+// Sink are small interfaces a caller implements with a few lines around
+// their existing kafka-go Reader/Writer or nats.go Subscription/Conn,
+// so adopting Run doesn't pull a broker dependency into every consumer
+// of this module.
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/shamspias/reve-go/image"
+)
+
+// Message is a single transport-agnostic event off a Kafka topic or
+// NATS subject, carrying a JSON-encoded image.CreateParams payload
+// (the same field names accepted by image.LoadBatchFromJSONL).
+type Message struct {
+	// Data is the raw message payload.
+	Data []byte
+
+	// Ack acknowledges the message once its manifest has been
+	// published, e.g. committing a Kafka offset or acking a NATS
+	// JetStream message. Leave nil for brokers without an ack step.
+	Ack func() error
+}
+
+// Source delivers Messages from an event stream.
+//
+// Example (wrapping a kafka-go Reader):
+//
+//	type kafkaSource struct{ r *kafka.Reader }
+//
+//	func (s kafkaSource) Messages(ctx context.Context) (<-chan stream.Message, error) {
+//		out := make(chan stream.Message)
+//		go func() {
+//			defer close(out)
+//			for {
+//				m, err := s.r.FetchMessage(ctx)
+//				if err != nil {
+//					return
+//				}
+//				out <- stream.Message{
+//					Data: m.Value,
+//					Ack:  func() error { return s.r.CommitMessages(ctx, m) },
+//				}
+//			}
+//		}()
+//		return out, nil
+//	}
+type Source interface {
+	// Messages returns a channel of incoming messages, closed when the
+	// source is exhausted or ctx is cancelled.
+	Messages(ctx context.Context) (<-chan Message, error)
+}
+
+// Sink publishes a result Manifest (marshaled to JSON) to a Kafka
+// topic, NATS subject, or other destination.
+type Sink interface {
+	Publish(ctx context.Context, data []byte) error
+}
+
+// Manifest is the JSON shape Run publishes to Sink for each message it
+// processes.
+type Manifest struct {
+	RequestID   string `json:"request_id,omitempty"`
+	CreditsUsed int    `json:"credits_used,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// RunnerConfig configures Run.
+type RunnerConfig struct {
+	// Concurrency is the max number of messages processed at once.
+	// Default: 4.
+	Concurrency int
+}
+
+// Run consumes image.CreateParams from source, generates each one via
+// svc.Create, and publishes a Manifest of the outcome to sink,
+// acknowledging each message (when it carries an Ack) only after its
+// manifest has been published. Run blocks until source's message
+// channel closes or ctx is cancelled, then returns the first error
+// encountered, if any.
+//
+// Example:
+//
+//	err := stream.Run(ctx, client.Images, kafkaSource{reader}, kafkaSink{writer}, nil)
+func Run(ctx context.Context, svc *image.Service, source Source, sink Sink, config *RunnerConfig) error {
+	if config == nil {
+		config = &RunnerConfig{Concurrency: 4}
+	}
+	concurrency := config.Concurrency
+	if concurrency < 1 {
+		concurrency = 4
+	}
+
+	messages, err := source.Messages(ctx)
+	if err != nil {
+		return fmt.Errorf("stream: open source: %w", err)
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	recordErr := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+loop:
+	for {
+		select {
+		case msg, ok := <-messages:
+			if !ok {
+				break loop
+			}
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				recordErr(ctx.Err())
+				break loop
+			}
+			wg.Add(1)
+			go func(msg Message) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				if err := process(ctx, svc, sink, msg); err != nil {
+					recordErr(err)
+				}
+			}(msg)
+		case <-ctx.Done():
+			recordErr(ctx.Err())
+			break loop
+		}
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+func process(ctx context.Context, svc *image.Service, sink Sink, msg Message) error {
+	var manifest Manifest
+
+	var params image.CreateParams
+	if err := json.Unmarshal(msg.Data, &params); err != nil {
+		manifest.Error = err.Error()
+	} else if result, err := svc.Create(ctx, &params); err != nil {
+		manifest.Error = err.Error()
+	} else {
+		manifest.RequestID = result.RequestID
+		manifest.CreditsUsed = result.CreditsUsed
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("stream: marshal manifest: %w", err)
+	}
+	if err := sink.Publish(ctx, data); err != nil {
+		return fmt.Errorf("stream: publish manifest: %w", err)
+	}
+	if msg.Ack != nil {
+		return msg.Ack()
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Package temporal provides plain functions shaped for registration as
+// Temporal activities (see pkg.go.dev/go.temporal.io/sdk/activity),
+// wrapping image.Service calls behind JSON-serializable input/output
+// structs instead of requiring the Temporal SDK as a dependency of this
+// module. A caller registers Activities' methods on their own
+// worker.Worker and invokes them from a workflow with
+// workflow.ExecuteActivity, same as any other activity.
+//
+// Example (registration):
+//
+//	acts := temporal.New(client.Images)
+//	w := worker.New(temporalClient, "reve-task-queue", worker.Options{})
+//	w.RegisterActivity(acts.CreateActivity)
+//	w.RegisterActivity(acts.EditActivity)
+//	w.RegisterActivity(acts.RemixActivity)
+package temporal
+
+import (
+	"context"
+
+	"github.com/shamspias/reve-go/image"
+	"github.com/shamspias/reve-go/types"
+)
+
+// Activities wraps an *image.Service with Temporal-activity-shaped
+// methods: a single struct input, a single pointer output, and an error
+// Temporal's own retry policy can act on (activities should not swallow
+// errors the way a batch helper would).
+type Activities struct {
+	Images *image.Service
+}
+
+// New returns Activities backed by images.
+func New(images *image.Service) *Activities {
+	return &Activities{Images: images}
+}
+
+// ActivityResult is the JSON-serializable shape every activity in this
+// package returns on success.
+type ActivityResult struct {
+	Image            string
+	RequestID        string
+	CreditsUsed      int
+	CreditsRemaining int
+	Seed             int64
+}
+
+// CreateInput is CreateActivity's input.
+type CreateInput struct {
+	Params *image.CreateParams
+}
+
+// CreateActivity generates an image from a text prompt. Register it on
+// a Temporal worker and call it from a workflow with
+// workflow.ExecuteActivity(ctx, acts.CreateActivity, temporal.CreateInput{...}).
+func (a *Activities) CreateActivity(ctx context.Context, input CreateInput) (*ActivityResult, error) {
+	result, err := a.Images.Create(ctx, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	return toActivityResult(result), nil
+}
+
+// EditInput is EditActivity's input.
+type EditInput struct {
+	Params *image.EditParams
+}
+
+// EditActivity modifies an existing image with a text instruction.
+func (a *Activities) EditActivity(ctx context.Context, input EditInput) (*ActivityResult, error) {
+	result, err := a.Images.Edit(ctx, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	return toActivityResult(result), nil
+}
+
+// RemixInput is RemixActivity's input.
+type RemixInput struct {
+	Params *image.RemixParams
+}
+
+// RemixActivity combines reference images with a text prompt.
+func (a *Activities) RemixActivity(ctx context.Context, input RemixInput) (*ActivityResult, error) {
+	result, err := a.Images.Remix(ctx, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	return toActivityResult(result), nil
+}
+
+func toActivityResult(r *types.Result) *ActivityResult {
+	return &ActivityResult{
+		Image:            r.Image,
+		RequestID:        r.RequestID,
+		CreditsUsed:      r.CreditsUsed,
+		CreditsRemaining: r.CreditsRemaining,
+		Seed:             r.Seed,
+	}
+}
@@ -0,0 +1,90 @@
+// Package tempfile manages temporary files used for streaming large raw
+// responses to disk, with centralized cleanup so crashed or cancelled
+// workers don't leak gigabytes in the temp directory.
+package tempfile
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Manager tracks temp files created under a configurable directory and
+// removes them on Close or Release.
+type Manager struct {
+	dir string
+
+	mu    sync.Mutex
+	files map[string]struct{}
+}
+
+// NewManager creates a Manager rooted at dir. If dir is empty, os.TempDir
+// is used.
+func NewManager(dir string) *Manager {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return &Manager{dir: dir, files: make(map[string]struct{})}
+}
+
+// Create opens a new tracked temp file with the given name pattern (as
+// accepted by os.CreateTemp). The caller must call m.Release(f.Name())
+// once the file is no longer needed, or m.Close() to clean up everything.
+func (m *Manager) Create(pattern string) (*os.File, error) {
+	f, err := os.CreateTemp(m.dir, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("tempfile: create: %w", err)
+	}
+
+	m.mu.Lock()
+	m.files[f.Name()] = struct{}{}
+	m.mu.Unlock()
+
+	return f, nil
+}
+
+// Release removes a single tracked temp file immediately, e.g. after it
+// has been consumed or when an operation using it failed.
+func (m *Manager) Release(path string) error {
+	m.mu.Lock()
+	_, tracked := m.files[path]
+	delete(m.files, path)
+	m.mu.Unlock()
+
+	if !tracked {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("tempfile: remove %s: %w", path, err)
+	}
+	return nil
+}
+
+// Forget stops tracking path without deleting it, for callers that take
+// ownership of the file (e.g. moving it to a final destination).
+func (m *Manager) Forget(path string) {
+	m.mu.Lock()
+	delete(m.files, path)
+	m.mu.Unlock()
+}
+
+// Close removes every temp file still tracked by the Manager. It is safe
+// to call from a defer at the end of a worker's lifetime to guarantee no
+// leaks on error or early exit.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	paths := make([]string, 0, len(m.files))
+	for p := range m.files {
+		paths = append(paths, p)
+	}
+	m.files = make(map[string]struct{})
+	m.mu.Unlock()
+
+	var firstErr error
+	for _, p := range paths {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) && firstErr == nil {
+			firstErr = fmt.Errorf("tempfile: remove %s: %w", p, err)
+		}
+	}
+	return firstErr
+}
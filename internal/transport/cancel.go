@@ -0,0 +1,82 @@
+package transport
+
+import (
+	"fmt"
+	"time"
+)
+
+// Reason is implemented by every error the transport returns when it
+// aborts a call before (or instead of) getting a usable response --
+// the circuit breaker tripping, a RetryBudget running dry, a context
+// deadline too tight to bother starting, or local load shedding --
+// so a caller can show an accurate, specific message instead of a
+// generic "request failed". See CircuitOpenError, RetryBudgetExceededError,
+// DeadlineError, and RateLimitShedError.
+type Reason interface {
+	error
+
+	// Reason returns a short, stable, machine-readable label for the
+	// abort cause, e.g. "circuit_open".
+	Reason() string
+}
+
+// RetryBudgetExceededError is returned instead of a retry's last
+// transient error when the Client's RetryBudget has no tokens left to
+// spend on another attempt, so the abort cause doesn't look identical
+// to the underlying error it stopped retrying.
+type RetryBudgetExceededError struct {
+	// LastErr is the error the most recent attempt failed with.
+	LastErr error
+}
+
+// Error implements the error interface.
+func (e *RetryBudgetExceededError) Error() string {
+	return fmt.Sprintf("reve: retry budget exhausted: %v", e.LastErr)
+}
+
+// Reason implements Reason.
+func (e *RetryBudgetExceededError) Reason() string {
+	return "retry_budget_exceeded"
+}
+
+// Unwrap returns LastErr.
+func (e *RetryBudgetExceededError) Unwrap() error {
+	return e.LastErr
+}
+
+// DeadlineError is returned when ctx has less time left than
+// MinDeadlineMargin, so the request is never attempted instead of
+// starting one almost certain to be cancelled mid-flight. See
+// WithMinDeadlineMargin.
+type DeadlineError struct {
+	// Remaining is how much time was left on ctx's deadline.
+	Remaining time.Duration
+
+	// Margin is the configured MinDeadlineMargin that rejected it.
+	Margin time.Duration
+}
+
+// Error implements the error interface.
+func (e *DeadlineError) Error() string {
+	return fmt.Sprintf("reve: %s left on context deadline, under the %s minimum margin -- not attempting the request", e.Remaining, e.Margin)
+}
+
+// Reason implements Reason.
+func (e *DeadlineError) Reason() string {
+	return "deadline_admission"
+}
+
+// RateLimitShedError is returned when MaxConcurrentRequests is already
+// saturated, so the request is rejected immediately instead of queuing
+// behind the in-flight ones. See WithMaxConcurrentRequests.
+type RateLimitShedError struct{}
+
+// Error implements the error interface.
+func (e *RateLimitShedError) Error() string {
+	return "reve: shed: too many concurrent requests"
+}
+
+// Reason implements Reason.
+func (e *RateLimitShedError) Reason() string {
+	return "rate_limit_shed"
+}
@@ -0,0 +1,112 @@
+package transport
+
+import (
+	"sync"
+	"time"
+)
+
+// CircuitOpenError is returned when the circuit breaker is open and
+// fails requests fast instead of hitting the network. It implements
+// Reason, so callers can tell this abort apart from a generic failure.
+type CircuitOpenError struct{}
+
+// Error implements the error interface.
+func (e *CircuitOpenError) Error() string {
+	return "reve: circuit breaker open"
+}
+
+// Reason implements Reason.
+func (e *CircuitOpenError) Reason() string {
+	return "circuit_open"
+}
+
+// ErrCircuitOpen is returned when the circuit breaker is open and fails
+// requests fast instead of hitting the network.
+var ErrCircuitOpen error = &CircuitOpenError{}
+
+// circuitState is the state of a CircuitBreaker.
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// CircuitBreaker fails requests fast after threshold consecutive failures,
+// instead of hammering an unhealthy API. After cooldown it allows a single
+// probe request through (half-open); success closes the circuit, failure
+// re-opens it.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu         sync.Mutex
+	state      circuitState
+	failures   int
+	openedAt   time.Time
+	probeInUse bool
+}
+
+// NewCircuitBreaker creates a CircuitBreaker that opens after threshold
+// consecutive failures and stays open for cooldown before probing again.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow reports whether a request may proceed, reserving the single
+// half-open probe slot if applicable.
+func (cb *CircuitBreaker) Allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case circuitClosed:
+		return nil
+	case circuitOpen:
+		if time.Since(cb.openedAt) < cb.cooldown {
+			return ErrCircuitOpen
+		}
+		cb.state = circuitHalfOpen
+		cb.probeInUse = true
+		return nil
+	case circuitHalfOpen:
+		if cb.probeInUse {
+			return ErrCircuitOpen
+		}
+		cb.probeInUse = true
+		return nil
+	}
+	return nil
+}
+
+// RecordSuccess resets the breaker to closed.
+func (cb *CircuitBreaker) RecordSuccess() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.state = circuitClosed
+	cb.failures = 0
+	cb.probeInUse = false
+}
+
+// RecordFailure registers a failure, opening the circuit once threshold
+// consecutive failures accumulate (or immediately if the failing request
+// was the half-open probe).
+func (cb *CircuitBreaker) RecordFailure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == circuitHalfOpen {
+		cb.state = circuitOpen
+		cb.openedAt = time.Now()
+		cb.probeInUse = false
+		return
+	}
+
+	cb.failures++
+	cb.probeInUse = false
+	if cb.failures >= cb.threshold {
+		cb.state = circuitOpen
+		cb.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,86 @@
+package transport
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	cb := NewCircuitBreaker(3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if err := cb.Allow(); err != nil {
+			t.Fatalf("Allow() #%d error = %v, want nil (breaker should still be closed)", i, err)
+		}
+		cb.RecordFailure()
+	}
+
+	if err := cb.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Allow() after threshold failures = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestCircuitBreakerSuccessResetsFailureCount(t *testing.T) {
+	cb := NewCircuitBreaker(2, time.Minute)
+
+	cb.RecordFailure()
+	cb.RecordSuccess()
+	cb.RecordFailure()
+
+	if err := cb.Allow(); err != nil {
+		t.Errorf("Allow() after a success reset the count = %v, want nil", err)
+	}
+}
+
+func TestCircuitBreakerHalfOpenAfterCooldown(t *testing.T) {
+	cb := NewCircuitBreaker(1, 10*time.Millisecond)
+
+	cb.RecordFailure()
+	if err := cb.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Allow() while open = %v, want ErrCircuitOpen", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if err := cb.Allow(); err != nil {
+		t.Fatalf("Allow() after cooldown (half-open probe) = %v, want nil", err)
+	}
+	if err := cb.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Allow() for a second concurrent half-open request = %v, want ErrCircuitOpen (only one probe allowed)", err)
+	}
+}
+
+func TestCircuitBreakerHalfOpenFailureReopens(t *testing.T) {
+	cb := NewCircuitBreaker(1, 10*time.Millisecond)
+
+	cb.RecordFailure()
+	time.Sleep(20 * time.Millisecond)
+
+	if err := cb.Allow(); err != nil {
+		t.Fatalf("Allow() for the probe = %v, want nil", err)
+	}
+	cb.RecordFailure()
+
+	if err := cb.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Allow() right after a failed probe = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestCircuitBreakerHalfOpenSuccessCloses(t *testing.T) {
+	cb := NewCircuitBreaker(1, 10*time.Millisecond)
+
+	cb.RecordFailure()
+	time.Sleep(20 * time.Millisecond)
+
+	if err := cb.Allow(); err != nil {
+		t.Fatalf("Allow() for the probe = %v, want nil", err)
+	}
+	cb.RecordSuccess()
+
+	for i := 0; i < 5; i++ {
+		if err := cb.Allow(); err != nil {
+			t.Errorf("Allow() #%d after probe success = %v, want nil (breaker should be closed)", i, err)
+		}
+	}
+}
@@ -8,9 +8,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"golang.org/x/net/proxy"
@@ -18,13 +20,32 @@ import (
 
 // Client handles HTTP communication with the Reve API.
 type Client struct {
-	httpClient *http.Client
-	baseURL    string
-	apiKey     string
-	userAgent  string
-	debug      bool
-	logger     Logger
-	retrier    *Retrier
+	httpClient      *http.Client
+	baseURL         string
+	apiKey          string
+	keys            *KeyRotator
+	keyProvider     KeyProvider
+	historyRecorder HistoryRecorder
+	userAgent       string
+	debug           bool
+	logger          Logger
+	slogger         *slog.Logger
+
+	binaryUploads    bool
+	compression      bool
+	captureErrorBody bool
+
+	// mu guards every field below, all of which Reload can swap on a
+	// live Client. Do and DoRaw take a snapshot of them once per call
+	// (see snapshot) instead of reading them field-by-field throughout,
+	// so a Reload mid-call can't tear a single call's view of them.
+	mu                sync.RWMutex
+	retrier           *Retrier
+	breaker           *CircuitBreaker
+	hedgeDelay        time.Duration
+	hedgeMaxHedges    int
+	minDeadlineMargin time.Duration
+	inflight          chan struct{}
 }
 
 // Logger is a function type for logging.
@@ -32,16 +53,95 @@ type Logger func(format string, args ...any)
 
 // Config holds transport configuration.
 type Config struct {
-	BaseURL      string
-	APIKey       string
+	BaseURL string
+	APIKey  string
+
+	// Keys, if set, overrides APIKey with a rotating pool of keys (see
+	// NewKeyRotator). Used by reve.NewClientWithKeys.
+	Keys *KeyRotator
+
+	// KeyProvider, if set, overrides APIKey and Keys, fetching the key to
+	// use for each request dynamically. Used by reve.WithKeyProvider.
+	KeyProvider KeyProvider
+
+	// HistoryRecorder, if set, receives an entry after every request
+	// attempt, used to back reve.Client.SupportBundle.
+	HistoryRecorder HistoryRecorder
+
 	UserAgent    string
 	Timeout      time.Duration
 	MaxRetries   int
 	RetryMinWait time.Duration
 	RetryMaxWait time.Duration
-	Debug        bool
-	Logger       Logger
-	Transport    http.RoundTripper
+
+	// BackoffPolicy overrides the default capped-exponential-with-jitter
+	// wait between retries. Nil uses ExponentialBackoff{RetryMinWait,
+	// RetryMaxWait}. Used by reve.WithBackoffPolicy.
+	BackoffPolicy BackoffPolicy
+
+	// RetryBudget, if set, is shared across every request the client
+	// makes, capping what fraction of total traffic may be retries. Nil
+	// imposes no cap. Used by reve.WithRetryBudget.
+	RetryBudget *RetryBudget
+
+	Debug      bool
+	Logger     Logger
+	SlogLogger *slog.Logger
+	Transport  http.RoundTripper
+
+	// CircuitThreshold is the number of consecutive failures that opens
+	// the circuit breaker. Zero disables the breaker.
+	CircuitThreshold int
+
+	// CircuitCooldown is how long the breaker stays open before allowing
+	// a half-open probe request.
+	CircuitCooldown time.Duration
+
+	// HedgeDelay is how long to wait for a response before issuing a
+	// duplicate hedged request. Zero disables hedging.
+	HedgeDelay time.Duration
+
+	// HedgeMaxHedges is the maximum number of extra hedged requests issued
+	// per logical call, on top of the original.
+	HedgeMaxHedges int
+
+	// BinaryUploads, when true, sends requests carrying Request.Files as
+	// multipart/form-data instead of base64-encoding the images into the
+	// JSON body, streaming each file's Reader directly into the request
+	// rather than buffering it. Requests without Files are unaffected. Used by
+	// reve.WithBinaryUploads.
+	BinaryUploads bool
+
+	// Compression, when true, gzip-compresses JSON request bodies and
+	// sends them with Content-Encoding: gzip, cutting upload size for
+	// large base64-encoded reference images. Response decompression
+	// needs no opt-in: Go's http.Transport already negotiates and
+	// decompresses gzip responses automatically. Multipart bodies
+	// (see BinaryUploads) are left uncompressed, since image formats
+	// are already compressed and gzipping them again wastes CPU for no
+	// size benefit. Used by reve.WithCompression.
+	Compression bool
+
+	// CaptureErrorBody, when true, attaches a redacted copy of the
+	// request body to APIError.RequestBody for 4xx responses, so "why
+	// was this rejected" is debuggable from a logged error alone. Image
+	// payload fields are replaced with their size, never their content.
+	// Used by reve.WithErrorBodyCapture.
+	CaptureErrorBody bool
+
+	// MinDeadlineMargin, if set, makes Do/DoRaw fail fast with a
+	// *DeadlineError instead of attempting a request when ctx has less
+	// than this much time left on its deadline -- the request would
+	// almost certainly be cancelled mid-flight anyway. Zero disables
+	// the check. Used by reve.WithMinDeadlineMargin.
+	MinDeadlineMargin time.Duration
+
+	// MaxConcurrentRequests, if set, caps how many Do/DoRaw calls this
+	// Client runs at once; a call made while the cap is already
+	// saturated fails immediately with a *RateLimitShedError instead of
+	// queuing behind the in-flight ones. Zero disables the cap. Used by
+	// reve.WithMaxConcurrentRequests.
+	MaxConcurrentRequests int
 }
 
 // New creates a new transport client.
@@ -54,17 +154,55 @@ func New(cfg *Config) *Client {
 		httpClient.Transport = cfg.Transport
 	}
 
+	var breaker *CircuitBreaker
+	if cfg.CircuitThreshold > 0 {
+		breaker = NewCircuitBreaker(cfg.CircuitThreshold, cfg.CircuitCooldown)
+	}
+
+	policy := cfg.BackoffPolicy
+	if policy == nil {
+		policy = ExponentialBackoff{Min: cfg.RetryMinWait, Max: cfg.RetryMaxWait}
+	}
+	retrier := NewRetrierWithPolicy(cfg.MaxRetries, policy)
+	if cfg.RetryBudget != nil {
+		retrier = retrier.WithBudget(cfg.RetryBudget)
+	}
+
 	return &Client{
-		httpClient: httpClient,
-		baseURL:    cfg.BaseURL,
-		apiKey:     cfg.APIKey,
-		userAgent:  cfg.UserAgent,
-		debug:      cfg.Debug,
-		logger:     cfg.Logger,
-		retrier:    NewRetrier(cfg.MaxRetries, cfg.RetryMinWait, cfg.RetryMaxWait),
+		httpClient:      httpClient,
+		baseURL:         cfg.BaseURL,
+		apiKey:          cfg.APIKey,
+		keys:            cfg.Keys,
+		keyProvider:     cfg.KeyProvider,
+		historyRecorder: cfg.HistoryRecorder,
+		userAgent:       cfg.UserAgent,
+		debug:           cfg.Debug,
+		logger:          cfg.Logger,
+		slogger:         cfg.SlogLogger,
+		retrier:         retrier,
+		breaker:         breaker,
+
+		hedgeDelay:     cfg.HedgeDelay,
+		hedgeMaxHedges: cfg.HedgeMaxHedges,
+
+		binaryUploads:    cfg.BinaryUploads,
+		compression:      cfg.Compression,
+		captureErrorBody: cfg.CaptureErrorBody,
+
+		minDeadlineMargin: cfg.MinDeadlineMargin,
+		inflight:          newInflightSemaphore(cfg.MaxConcurrentRequests),
 	}
 }
 
+// newInflightSemaphore returns a buffered channel sized for max concurrent
+// requests, or nil (meaning unbounded, no shedding) when max is zero.
+func newInflightSemaphore(max int) chan struct{} {
+	if max <= 0 {
+		return nil
+	}
+	return make(chan struct{}, max)
+}
+
 // Request represents an API request.
 type Request struct {
 	Method     string
@@ -72,6 +210,47 @@ type Request struct {
 	Body       any
 	Accept     string
 	Breadcrumb string
+
+	// Timeout bounds this request's whole call, retries included. The
+	// client's default timeout still bounds each attempt, so Timeout can
+	// shorten a request but cannot extend an attempt past that default.
+	// Zero adds no bound of its own.
+	Timeout time.Duration
+
+	// MaxRetries overrides the client's default retry count for this
+	// request only. Nil means use the client default.
+	MaxRetries *int
+
+	// Headers are additional headers merged into the outgoing request.
+	Headers map[string]string
+
+	// IdempotencyKey marks the request as safe to hedge or retry blindly.
+	// Only requests carrying a non-empty IdempotencyKey are eligible for
+	// hedging (see Config.HedgeDelay/HedgeMaxHedges).
+	IdempotencyKey string
+
+	// IfNoneMatch, if set, is sent as the If-None-Match header so the
+	// server can answer 304 Not Modified instead of re-sending a hosted
+	// result the caller already has cached.
+	IfNoneMatch string
+
+	// Partition names the quota share (see image.WithPartition) this
+	// request draws its credit budget from. Empty uses the default
+	// partition.
+	Partition string
+
+	// OnBytes, if set, is called as the response body is read, with the
+	// cumulative bytes read so far and the total from Content-Length (0
+	// if the server didn't send one). Only consulted by DoRaw, for
+	// reporting download progress on large binary results; see
+	// image.WithProgress.
+	OnBytes func(read, total int64)
+
+	// Files, if set and the client has BinaryUploads enabled, are sent
+	// as multipart/form-data file parts alongside Body (JSON-encoded
+	// into a "payload" field) instead of Body carrying base64 image
+	// fields. Ignored when BinaryUploads is off.
+	Files []*MultipartFile
 }
 
 // Response represents a JSON response.
@@ -79,6 +258,14 @@ type Response struct {
 	Body      []byte
 	Status    int
 	RequestID string
+
+	// Attempts is how many times Do tried the request, including the
+	// first attempt, before this response came back.
+	Attempts int
+
+	// Elapsed is the total wall-clock time Do spent on this call,
+	// across every attempt.
+	Elapsed time.Duration
 }
 
 // RawResponse represents a binary response.
@@ -90,20 +277,194 @@ type RawResponse struct {
 	RequestID        string
 	CreditsUsed      int
 	CreditsRemaining int
+
+	// ETag is the response's ETag header, if any, for use as a future
+	// If-None-Match revalidation value.
+	ETag string
+
+	// NotModified is true when the server answered 304 Not Modified to a
+	// request carrying If-None-Match; Data is empty in that case and the
+	// caller should keep using its cached copy.
+	NotModified bool
+
+	// Seed is the random seed actually used for the generation.
+	Seed int64
+
+	// Attempts is how many times DoRaw tried the request, including
+	// the first attempt, before this response came back.
+	Attempts int
+
+	// Elapsed is the total wall-clock time DoRaw spent on this call,
+	// across every attempt.
+	Elapsed time.Duration
 }
 
 // Do executes a request and returns JSON response.
 func (c *Client) Do(ctx context.Context, req *Request) (*Response, error) {
-	return c.retrier.Do(ctx, func() (*Response, error) {
-		return c.execute(ctx, req)
-	})
+	applyOverrides(ctx, req)
+	applyTraceparent(ctx, req)
+
+	ctx, cancel := c.withTimeout(ctx, req)
+	defer cancel()
+
+	snap := c.snapshot()
+
+	if err := checkDeadline(snap, ctx); err != nil {
+		return nil, err
+	}
+	release, err := acquireSlot(snap)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	callStarted := time.Now()
+	attemptNum := 0
+	var history []HistoryEntry
+	attempt := func(ctx context.Context) (*Response, error) {
+		return retrierFor(snap, req).Do(ctx, func() (*Response, error) {
+			var resp *Response
+			var err error
+			withPprofLabels(ctx, req, func(ctx context.Context) {
+				attemptNum++
+				started := time.Now()
+
+				if snap.breaker == nil {
+					resp, err = c.execute(ctx, req)
+					c.logStructured(req, resp, err, attemptNum, time.Since(started))
+					history = append(history, c.recordHistory(req, statusOf(resp), err, time.Since(started)))
+					return
+				}
+				if err = snap.breaker.Allow(); err != nil {
+					return
+				}
+				resp, err = c.execute(ctx, req)
+				c.logStructured(req, resp, err, attemptNum, time.Since(started))
+				history = append(history, c.recordHistory(req, statusOf(resp), err, time.Since(started)))
+				if err != nil {
+					snap.breaker.RecordFailure()
+					return
+				}
+				snap.breaker.RecordSuccess()
+			})
+			return resp, err
+		})
+	}
+
+	run := func() (*Response, error) {
+		if snap.hedgeMaxHedges > 0 && req.IdempotencyKey != "" {
+			return runHedged(ctx, snap.hedgeDelay, snap.hedgeMaxHedges, attempt)
+		}
+		return attempt(ctx)
+	}
+
+	call := run
+	if c.keys != nil && c.keys.policy == Failover {
+		call = func() (*Response, error) { return runFailover(c.keys, run) }
+	}
+
+	resp, err := runKeyRefresh(c.keyProvider, call)
+	elapsed := time.Since(callStarted)
+	if err != nil {
+		return nil, &RetryError{Attempts: attemptNum, Elapsed: elapsed, LastErr: err, History: history}
+	}
+	resp.Attempts = attemptNum
+	resp.Elapsed = elapsed
+	return resp, nil
 }
 
 // DoRaw executes a request and returns raw binary response.
 func (c *Client) DoRaw(ctx context.Context, req *Request) (*RawResponse, error) {
-	return c.retrier.DoRaw(ctx, func() (*RawResponse, error) {
-		return c.executeRaw(ctx, req)
-	})
+	applyOverrides(ctx, req)
+	applyTraceparent(ctx, req)
+
+	ctx, cancel := c.withTimeout(ctx, req)
+	defer cancel()
+
+	snap := c.snapshot()
+
+	if err := checkDeadline(snap, ctx); err != nil {
+		return nil, err
+	}
+	release, err := acquireSlot(snap)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	callStarted := time.Now()
+	attemptNum := 0
+	var history []HistoryEntry
+	attempt := func(ctx context.Context) (*RawResponse, error) {
+		return retrierFor(snap, req).DoRaw(ctx, func() (*RawResponse, error) {
+			var resp *RawResponse
+			var err error
+			withPprofLabels(ctx, req, func(ctx context.Context) {
+				attemptNum++
+				started := time.Now()
+
+				if snap.breaker == nil {
+					resp, err = c.executeRaw(ctx, req)
+					c.logStructuredRaw(req, resp, err, attemptNum, time.Since(started))
+					history = append(history, c.recordHistory(req, 0, err, time.Since(started)))
+					return
+				}
+				if err = snap.breaker.Allow(); err != nil {
+					return
+				}
+				resp, err = c.executeRaw(ctx, req)
+				c.logStructuredRaw(req, resp, err, attemptNum, time.Since(started))
+				history = append(history, c.recordHistory(req, 0, err, time.Since(started)))
+				if err != nil {
+					snap.breaker.RecordFailure()
+					return
+				}
+				snap.breaker.RecordSuccess()
+			})
+			return resp, err
+		})
+	}
+
+	run := func() (*RawResponse, error) {
+		if snap.hedgeMaxHedges > 0 && req.IdempotencyKey != "" {
+			return runHedged(ctx, snap.hedgeDelay, snap.hedgeMaxHedges, attempt)
+		}
+		return attempt(ctx)
+	}
+
+	call := run
+	if c.keys != nil && c.keys.policy == Failover {
+		call = func() (*RawResponse, error) { return runFailover(c.keys, run) }
+	}
+
+	resp, err := runKeyRefresh(c.keyProvider, call)
+	elapsed := time.Since(callStarted)
+	if err != nil {
+		return nil, &RetryError{Attempts: attemptNum, Elapsed: elapsed, LastErr: err, History: history}
+	}
+	resp.Attempts = attemptNum
+	resp.Elapsed = elapsed
+	return resp, nil
+}
+
+// currentAPIKey returns the key to authenticate with: the KeyProvider if
+// one is configured, otherwise the key rotator, otherwise the static key.
+func (c *Client) currentAPIKey(ctx context.Context) (string, error) {
+	if c.keyProvider != nil {
+		return c.keyProvider(ctx)
+	}
+	if c.keys != nil {
+		return c.keys.Current(), nil
+	}
+	return c.apiKey, nil
+}
+
+// withTimeout applies a per-request timeout override, if set.
+func (c *Client) withTimeout(ctx context.Context, req *Request) (context.Context, context.CancelFunc) {
+	if req.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, req.Timeout)
 }
 
 func (c *Client) execute(ctx context.Context, req *Request) (*Response, error) {
@@ -128,7 +489,11 @@ func (c *Client) execute(ctx context.Context, req *Request) (*Response, error) {
 	c.log("Response: status=%d", resp.StatusCode)
 
 	if resp.StatusCode >= 400 {
-		return nil, ParseError(resp, body)
+		apiErr := ParseError(resp, body)
+		if c.captureErrorBody {
+			apiErr.RequestBody = redactRequestBody(req.Body)
+		}
+		return nil, apiErr
 	}
 
 	return &Response{
@@ -154,10 +519,32 @@ func (c *Client) executeRaw(ctx context.Context, req *Request) (*RawResponse, er
 
 	if errCode := resp.Header.Get("X-Reve-Error-Code"); errCode != "" {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, ParseError(resp, body)
+		apiErr := ParseError(resp, body)
+		if c.captureErrorBody {
+			apiErr.RequestBody = redactRequestBody(req.Body)
+		}
+		return nil, apiErr
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		c.log("Response (raw): status=304 (not modified)")
+		return &RawResponse{
+			ETag:        resp.Header.Get("ETag"),
+			NotModified: true,
+			RequestID:   resp.Header.Get("X-Reve-Request-Id"),
+		}, nil
 	}
 
-	data, err := io.ReadAll(resp.Body)
+	body := resp.Body
+	if req.OnBytes != nil {
+		total := resp.ContentLength
+		if total < 0 {
+			total = 0
+		}
+		body = &progressReader{r: resp.Body, total: total, onBytes: req.OnBytes}
+	}
+
+	data, err := io.ReadAll(body)
 	if err != nil {
 		return nil, &RequestError{Op: "read response", Err: err}
 	}
@@ -172,6 +559,8 @@ func (c *Client) executeRaw(ctx context.Context, req *Request) (*RawResponse, er
 		RequestID:        resp.Header.Get("X-Reve-Request-Id"),
 		CreditsUsed:      parseIntHeader(resp, "X-Reve-Credits-Used"),
 		CreditsRemaining: parseIntHeader(resp, "X-Reve-Credits-Remaining"),
+		ETag:             resp.Header.Get("ETag"),
+		Seed:             parseInt64Header(resp, "X-Reve-Seed"),
 	}, nil
 }
 
@@ -183,12 +572,32 @@ func (c *Client) buildRequest(ctx context.Context, req *Request) (*http.Request,
 
 	var bodyReader io.Reader
 	var getBody func() (io.ReadCloser, error)
-
-	if req.Body != nil {
+	var contentEncoding string
+	contentType := "application/json"
+
+	if c.binaryUploads && len(req.Files) > 0 {
+		// Multipart bodies stream from each req.Files Reader and can't be
+		// re-read, so they're not eligible for transparent retry replay
+		// (getBody stays nil; the retrier still retries, it just can't
+		// resend this exact body).
+		body, ct, err := buildMultipartBody(req)
+		if err != nil {
+			return nil, err
+		}
+		bodyReader = body
+		contentType = ct
+	} else if req.Body != nil {
 		data, err := json.Marshal(req.Body)
 		if err != nil {
 			return nil, &RequestError{Op: "marshal", Err: err}
 		}
+		if c.compression {
+			data, err = gzipBytes(data)
+			if err != nil {
+				return nil, &RequestError{Op: "gzip", Err: err}
+			}
+			contentEncoding = "gzip"
+		}
 		bodyReader = bytes.NewReader(data)
 		bodyData := data
 		getBody = func() (io.ReadCloser, error) {
@@ -201,10 +610,18 @@ func (c *Client) buildRequest(ctx context.Context, req *Request) (*http.Request,
 		return nil, &RequestError{Op: "create request", Err: err}
 	}
 
+	apiKey, err := c.currentAPIKey(ctx)
+	if err != nil {
+		return nil, &RequestError{Op: "key provider", Err: err}
+	}
+
 	httpReq.GetBody = getBody
-	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
-	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	httpReq.Header.Set("Content-Type", contentType)
 	httpReq.Header.Set("User-Agent", c.userAgent)
+	if contentEncoding != "" {
+		httpReq.Header.Set("Content-Encoding", contentEncoding)
+	}
 
 	accept := "application/json"
 	if req.Accept != "" {
@@ -212,9 +629,23 @@ func (c *Client) buildRequest(ctx context.Context, req *Request) (*http.Request,
 	}
 	httpReq.Header.Set("Accept", accept)
 
+	if req.IfNoneMatch != "" {
+		httpReq.Header.Set("If-None-Match", req.IfNoneMatch)
+	}
+
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
 	return httpReq, nil
 }
 
+// BinaryUploadsEnabled reports whether the client was configured with
+// Config.BinaryUploads.
+func (c *Client) BinaryUploadsEnabled() bool {
+	return c.binaryUploads
+}
+
 func (c *Client) log(format string, args ...any) {
 	if !c.debug {
 		return
@@ -226,6 +657,56 @@ func (c *Client) log(format string, args ...any) {
 	}
 }
 
+// logStructured emits a structured slog record for one JSON-response
+// attempt, when a *slog.Logger has been configured via WithLogger. The
+// Authorization header (and therefore the API key) is never part of the
+// logged fields.
+func (c *Client) logStructured(req *Request, resp *Response, err error, attempt int, dur time.Duration) {
+	if c.slogger == nil {
+		return
+	}
+
+	attrs := []any{
+		"method", req.Method,
+		"path", req.Path,
+		"attempt", attempt,
+		"duration", dur,
+	}
+	if resp != nil {
+		attrs = append(attrs, "status", resp.Status, "request_id", resp.RequestID)
+	}
+
+	if err != nil {
+		c.slogger.Error("reve request failed", append(attrs, "error", err)...)
+		return
+	}
+	c.slogger.Info("reve request", attrs...)
+}
+
+// logStructuredRaw is the RawResponse counterpart of logStructured,
+// additionally reporting credits_used.
+func (c *Client) logStructuredRaw(req *Request, resp *RawResponse, err error, attempt int, dur time.Duration) {
+	if c.slogger == nil {
+		return
+	}
+
+	attrs := []any{
+		"method", req.Method,
+		"path", req.Path,
+		"attempt", attempt,
+		"duration", dur,
+	}
+	if resp != nil {
+		attrs = append(attrs, "request_id", resp.RequestID, "credits_used", resp.CreditsUsed)
+	}
+
+	if err != nil {
+		c.slogger.Error("reve request failed", append(attrs, "error", err)...)
+		return
+	}
+	c.slogger.Info("reve request", attrs...)
+}
+
 func parseIntHeader(resp *http.Response, key string) int {
 	val := resp.Header.Get(key)
 	if val == "" {
@@ -236,6 +717,58 @@ func parseIntHeader(resp *http.Response, key string) int {
 	return n
 }
 
+func parseInt64Header(resp *http.Response, key string) int64 {
+	val := resp.Header.Get(key)
+	if val == "" {
+		return 0
+	}
+	var n int64
+	fmt.Sscanf(val, "%d", &n)
+	return n
+}
+
+// PoolConfig tunes the per-host HTTP connection pool used by
+// CreatePooledTransport. Zero values fall back to Go's http.Transport
+// defaults for that field.
+type PoolConfig struct {
+	// MaxIdleConns caps idle connections kept open across all hosts.
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost caps idle connections kept open per host,
+	// worth raising above Go's default of 2 for a client that talks to
+	// one API host with high concurrency.
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost caps total (idle + active) connections per host.
+	// Zero means unlimited.
+	MaxConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept before
+	// being closed.
+	IdleConnTimeout time.Duration
+}
+
+// CreatePooledTransport creates a transport with cfg's per-host
+// connection pool limits, otherwise matching the other Create*Transport
+// helpers' dial and TLS settings.
+func CreatePooledTransport(cfg PoolConfig) http.RoundTripper {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       &tls.Config{MinVersion: tls.VersionTLS12},
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
 // CreateHTTPProxyTransport creates a transport with HTTP proxy.
 func CreateHTTPProxyTransport(proxyURL string) (http.RoundTripper, error) {
 	parsed, err := url.Parse(proxyURL)
@@ -250,6 +783,7 @@ func CreateHTTPProxyTransport(proxyURL string) (http.RoundTripper, error) {
 			KeepAlive: 30 * time.Second,
 		}).DialContext,
 		TLSClientConfig:       &tls.Config{MinVersion: tls.VersionTLS12},
+		ForceAttemptHTTP2:     true,
 		MaxIdleConns:          100,
 		IdleConnTimeout:       90 * time.Second,
 		TLSHandshakeTimeout:   10 * time.Second,
@@ -257,6 +791,27 @@ func CreateHTTPProxyTransport(proxyURL string) (http.RoundTripper, error) {
 	}, nil
 }
 
+// CreateProxyFuncTransport creates a transport that consults fn for
+// every request, like http.ProxyURL and http.ProxyFromEnvironment but
+// for callers needing PAC-like rules (e.g. a different proxy per
+// region's egress point). Returning a nil URL and nil error means
+// connect directly.
+func CreateProxyFuncTransport(fn func(*http.Request) (*url.URL, error)) http.RoundTripper {
+	return &http.Transport{
+		Proxy: fn,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       &tls.Config{MinVersion: tls.VersionTLS12},
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
 // CreateSOCKS5ProxyTransport creates a transport with SOCKS5 proxy.
 func CreateSOCKS5ProxyTransport(addr, username, password string) (http.RoundTripper, error) {
 	var auth *proxy.Auth
@@ -274,6 +829,40 @@ func CreateSOCKS5ProxyTransport(addr, username, password string) (http.RoundTrip
 			return dialer.Dial(network, addr)
 		},
 		TLSClientConfig:       &tls.Config{MinVersion: tls.VersionTLS12},
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}, nil
+}
+
+// CreateSOCKS5FallbackTransport creates a transport like
+// CreateSOCKS5ProxyTransport, but falls back to a direct connection
+// when the SOCKS5 dial fails, instead of failing the request. Useful
+// where the SOCKS5 endpoint is a best-effort egress point rather than a
+// hard network boundary.
+func CreateSOCKS5FallbackTransport(addr, username, password string) (http.RoundTripper, error) {
+	var auth *proxy.Auth
+	if username != "" {
+		auth = &proxy.Auth{User: username, Password: password}
+	}
+
+	dialer, err := proxy.SOCKS5("tcp", addr, auth, proxy.Direct)
+	if err != nil {
+		return nil, err
+	}
+	direct := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+
+	return &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if conn, err := dialer.Dial(network, addr); err == nil {
+				return conn, nil
+			}
+			return direct.DialContext(ctx, network, addr)
+		},
+		TLSClientConfig:       &tls.Config{MinVersion: tls.VersionTLS12},
+		ForceAttemptHTTP2:     true,
 		MaxIdleConns:          100,
 		IdleConnTimeout:       90 * time.Second,
 		TLSHandshakeTimeout:   10 * time.Second,
@@ -290,6 +879,7 @@ func CreateEnvProxyTransport() http.RoundTripper {
 			KeepAlive: 30 * time.Second,
 		}).DialContext,
 		TLSClientConfig:       &tls.Config{MinVersion: tls.VersionTLS12},
+		ForceAttemptHTTP2:     true,
 		MaxIdleConns:          100,
 		IdleConnTimeout:       90 * time.Second,
 		TLSHandshakeTimeout:   10 * time.Second,
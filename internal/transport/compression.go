@@ -0,0 +1,19 @@
+package transport
+
+import (
+	"bytes"
+	"compress/gzip"
+)
+
+// gzipBytes compresses data with gzip at the default compression level.
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,27 @@
+package transport
+
+import "context"
+
+// Override customizes a Request from values carried on its context,
+// for code paths that don't have direct access to the Request or
+// RequestOption list because ctx was threaded through several layers
+// before reaching Do or DoRaw. See ContextWithOverrides and the public
+// constructor, reve.ContextWithOptions.
+type Override func(*Request)
+
+type overridesKey struct{}
+
+// ContextWithOverrides returns a context carrying overrides to apply to
+// every request built while executing with it, after any per-call
+// RequestOption values already set on the Request.
+func ContextWithOverrides(ctx context.Context, overrides ...Override) context.Context {
+	return context.WithValue(ctx, overridesKey{}, overrides)
+}
+
+// applyOverrides applies any Override values carried on ctx to req.
+func applyOverrides(ctx context.Context, req *Request) {
+	overrides, _ := ctx.Value(overridesKey{}).([]Override)
+	for _, o := range overrides {
+		o(req)
+	}
+}
@@ -0,0 +1,50 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// imageFields are the request body keys known to carry base64 image
+// payloads. redactRequestBody replaces their values with a size
+// summary instead of the image data itself.
+var imageFields = map[string]bool{
+	"reference_image":  true,
+	"reference_images": true,
+}
+
+// redactRequestBody returns body (typically a *CreateParams, *EditParams,
+// or *RemixParams) marshaled to a map with any image payload field
+// replaced by its size, so an APIError's RequestBody is safe to log:
+// prompt text and param values survive, image bytes don't.
+func redactRequestBody(body any) map[string]any {
+	if body == nil {
+		return nil
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	for key, value := range fields {
+		if !imageFields[key] {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			fields[key] = fmt.Sprintf("<image: %d base64 chars>", len(v))
+		case []any:
+			sizes := make([]string, len(v))
+			for i, img := range v {
+				if s, ok := img.(string); ok {
+					sizes[i] = fmt.Sprintf("<image: %d base64 chars>", len(s))
+				}
+			}
+			fields[key] = sizes
+		}
+	}
+	return fields
+}
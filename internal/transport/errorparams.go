@@ -0,0 +1,75 @@
+package transport
+
+import "encoding/json"
+
+// Reve does not publish a schema for APIError.Params; the shapes below
+// are this SDK's best-effort reading of the fields observed in error
+// responses for each ErrorCode. DecodeParams and the typed accessors
+// degrade gracefully (returning ok=false, or a zero-value struct) when
+// a response doesn't match, so a server-side change to Params never
+// turns into a panic here -- only a missed typed read, with Params
+// itself still available as a fallback.
+
+// DecodeParams decodes e.Params into v, a pointer to one of the
+// *Details structs below (or a caller's own struct shaped like the
+// relevant Params payload). It round-trips through JSON, so v's fields
+// should use the same json tags the API uses for that error code's
+// Params.
+func (e *APIError) DecodeParams(v any) error {
+	data, err := json.Marshal(e.Params)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// MissingParamDetails is the Params shape for ErrCodeMissingParam.
+type MissingParamDetails struct {
+	Param string `json:"param"`
+}
+
+// MissingParam returns the name of the missing required parameter for
+// an ErrCodeMissingParam error, and whether Params decoded one.
+func (e *APIError) MissingParam() (string, bool) {
+	var d MissingParamDetails
+	if e.DecodeParams(&d) != nil || d.Param == "" {
+		return "", false
+	}
+	return d.Param, true
+}
+
+// LimitDetails is the Params shape for errors reporting that a value
+// exceeded an allowed maximum, such as ErrCodePromptTooLong or
+// ErrCodeIndexOutOfBounds.
+type LimitDetails struct {
+	Max    float64 `json:"max"`
+	Actual float64 `json:"actual"`
+}
+
+// MaxAllowed returns the maximum allowed value from a limit-style
+// error's Params (e.g. the prompt length limit on ErrCodePromptTooLong),
+// and whether Params decoded one.
+func (e *APIError) MaxAllowed() (float64, bool) {
+	var d LimitDetails
+	if e.DecodeParams(&d) != nil || d.Max == 0 {
+		return 0, false
+	}
+	return d.Max, true
+}
+
+// ContentViolationDetails is the Params shape for
+// ErrCodeContentViolation.
+type ContentViolationDetails struct {
+	Categories []string `json:"categories"`
+}
+
+// ViolationCategories returns the content policy categories a prompt or
+// image tripped, for an ErrCodeContentViolation error, and whether
+// Params decoded any.
+func (e *APIError) ViolationCategories() ([]string, bool) {
+	var d ContentViolationDetails
+	if e.DecodeParams(&d) != nil || len(d.Categories) == 0 {
+		return nil, false
+	}
+	return d.Categories, true
+}
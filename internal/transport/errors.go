@@ -2,8 +2,13 @@ package transport
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 // ErrorCode represents API error codes.
@@ -21,6 +26,18 @@ const (
 	ErrCodeInternal          ErrorCode = "INTERNAL_ERROR"
 )
 
+// Sentinel errors for errors.Is, so callers can classify an error
+// without importing this internal package or type-asserting *APIError
+// themselves. *APIError.Is reports a match against whichever of these
+// its Code or StatusCode corresponds to; see reve.ErrRateLimited and
+// friends for the re-exported names most callers should use.
+var (
+	ErrRateLimited         = errors.New("reve: rate limited")
+	ErrInsufficientCredits = errors.New("reve: insufficient credits")
+	ErrContentViolation    = errors.New("reve: content policy violation")
+	ErrAuth                = errors.New("reve: authentication failed")
+)
+
 // APIError represents an API error.
 type APIError struct {
 	Code       ErrorCode      `json:"error_code"`
@@ -28,6 +45,17 @@ type APIError struct {
 	Params     map[string]any `json:"params,omitempty"`
 	StatusCode int            `json:"-"`
 	RequestID  string         `json:"-"`
+
+	// RetryAfter is how long the API asked callers to wait before
+	// retrying, parsed from the Retry-After header. Zero when the
+	// response didn't include one.
+	RetryAfter time.Duration `json:"-"`
+
+	// RequestBody is a redacted copy of the request that produced this
+	// error, present only when Config.CaptureErrorBody is set and the
+	// status code is 4xx. Image payload fields are replaced with their
+	// size so the body stays log-safe. See WithErrorBodyCapture.
+	RequestBody map[string]any `json:"-"`
 }
 
 // Error implements the error interface.
@@ -64,6 +92,24 @@ func (e *APIError) IsAuthError() bool {
 	return e.Code == ErrCodeInvalidAPIKey || e.StatusCode == http.StatusUnauthorized
 }
 
+// Is reports whether target is one of the sentinel errors above that
+// classifies e, so errors.Is(err, ErrRateLimited) (or the reve.ErrXxx
+// re-exports) works without the caller type-asserting *APIError.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.IsRateLimit()
+	case ErrInsufficientCredits:
+		return e.IsInsufficientFunds()
+	case ErrContentViolation:
+		return e.IsContentViolation()
+	case ErrAuth:
+		return e.IsAuthError()
+	default:
+		return false
+	}
+}
+
 // RequestError represents a request-level error.
 type RequestError struct {
 	Op  string
@@ -80,11 +126,38 @@ func (e *RequestError) Unwrap() error {
 	return e.Err
 }
 
+// Retryable reports whether e looks like a transient network failure
+// (connection reset, DNS hiccup, dropped connection mid-response, read
+// timeout) worth a retry, as opposed to a request the client built
+// wrong (Op "marshal", "create request") or a KeyProvider failure,
+// neither of which a retry would fix.
+func (e *RequestError) Retryable() bool {
+	switch e.Op {
+	case "http", "read response":
+		return isTransientNetErr(e.Err)
+	default:
+		return false
+	}
+}
+
+// isTransientNetErr reports whether err is the kind of network failure
+// that's often gone on the next attempt: a net.Error (timeouts,
+// connection refused/reset, DNS lookup failures) or a body stream
+// cut short by io.EOF/io.ErrUnexpectedEOF.
+func isTransientNetErr(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
 // ParseError parses an error response.
 func ParseError(resp *http.Response, body []byte) *APIError {
 	apiErr := &APIError{
 		StatusCode: resp.StatusCode,
 		RequestID:  resp.Header.Get("X-Reve-Request-Id"),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
 	}
 
 	if err := json.Unmarshal(body, apiErr); err != nil {
@@ -109,3 +182,21 @@ func ParseError(resp *http.Response, body []byte) *APIError {
 
 	return apiErr
 }
+
+// parseRetryAfter parses a Retry-After header value, which per RFC 9110
+// is either a number of seconds or an HTTP date. Returns zero if value
+// is empty or unparseable.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
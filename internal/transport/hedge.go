@@ -0,0 +1,60 @@
+package transport
+
+import (
+	"context"
+	"time"
+)
+
+// hedgeResult carries the outcome of one hedged attempt.
+type hedgeResult[T any] struct {
+	value T
+	err   error
+}
+
+// runHedged issues fn immediately, then issues additional copies every
+// delay until maxHedges extra attempts have been launched or one attempt
+// succeeds. The first successful result wins; all other in-flight
+// attempts are left to finish in the background and their results
+// discarded. Only used for idempotent requests carrying an IdempotencyKey,
+// since duplicate non-idempotent calls would double-apply.
+func runHedged[T any](ctx context.Context, delay time.Duration, maxHedges int, fn func(context.Context) (T, error)) (T, error) {
+	results := make(chan hedgeResult[T], maxHedges+1)
+
+	launch := func() {
+		go func() {
+			v, err := fn(ctx)
+			results <- hedgeResult[T]{value: v, err: err}
+		}()
+	}
+
+	launch()
+	launched := 1
+	failed := 0
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case r := <-results:
+			if r.err == nil {
+				return r.value, nil
+			}
+			failed++
+			lastErr := r.err
+			if failed >= launched && launched > maxHedges {
+				var zero T
+				return zero, lastErr
+			}
+		case <-timer.C:
+			if launched <= maxHedges {
+				launch()
+				launched++
+				timer.Reset(delay)
+			}
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunHedgedReturnsFirstSuccess(t *testing.T) {
+	var calls int32
+	fn := func(ctx context.Context) (string, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			time.Sleep(50 * time.Millisecond)
+			return "", errors.New("slow attempt failed")
+		}
+		return "hedged", nil
+	}
+
+	result, err := runHedged(context.Background(), 10*time.Millisecond, 2, fn)
+	if err != nil {
+		t.Fatalf("runHedged() error = %v", err)
+	}
+	if result != "hedged" {
+		t.Errorf("runHedged() = %q, want %q", result, "hedged")
+	}
+}
+
+func TestRunHedgedNoHedgeNeededOnFastSuccess(t *testing.T) {
+	var calls int32
+	fn := func(ctx context.Context) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "fast", nil
+	}
+
+	result, err := runHedged(context.Background(), time.Hour, 3, fn)
+	if err != nil {
+		t.Fatalf("runHedged() error = %v", err)
+	}
+	if result != "fast" {
+		t.Errorf("runHedged() = %q, want %q", result, "fast")
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("calls = %d, want 1 (no hedge should have fired before success)", got)
+	}
+}
+
+func TestRunHedgedAllAttemptsFail(t *testing.T) {
+	wantErr := errors.New("boom")
+	fn := func(ctx context.Context) (string, error) {
+		return "", wantErr
+	}
+
+	_, err := runHedged(context.Background(), 5*time.Millisecond, 2, fn)
+	if !errors.Is(err, wantErr) {
+		t.Errorf("runHedged() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestRunHedgedRespectsMaxHedges(t *testing.T) {
+	var calls int32
+	fn := func(ctx context.Context) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 35*time.Millisecond)
+	defer cancel()
+
+	_, _ = runHedged(ctx, 10*time.Millisecond, 1, fn)
+
+	if got := atomic.LoadInt32(&calls); got > 2 {
+		t.Errorf("calls = %d, want at most 2 (1 original + maxHedges=1)", got)
+	}
+}
@@ -0,0 +1,80 @@
+package transport
+
+import (
+	"fmt"
+	"time"
+)
+
+// HistoryEntry records one request attempt, giving tools like
+// reve.Client.SupportBundle a rolling transcript of recent activity
+// without requiring a logging setup.
+type HistoryEntry struct {
+	Time     time.Time
+	Method   string
+	Path     string
+	Status   int
+	Err      string
+	Duration time.Duration
+}
+
+// HistoryRecorder receives a HistoryEntry after every request attempt,
+// successful or not.
+type HistoryRecorder func(HistoryEntry)
+
+// RetryError wraps the final error from a failed Do/DoRaw call, whether
+// it made one attempt or several, carrying how many attempts ran, how long the call
+// took in total, and a per-attempt History, so a caller logging a
+// failure isn't left with just the last attempt's error.
+type RetryError struct {
+	// Attempts is how many times the request was tried, including the
+	// first attempt.
+	Attempts int
+
+	// Elapsed is the total wall-clock time across every attempt.
+	Elapsed time.Duration
+
+	// LastErr is the error from the final attempt.
+	LastErr error
+
+	// History holds one entry per attempt, in order.
+	History []HistoryEntry
+}
+
+// Error implements the error interface.
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("reve: failed after %d attempts in %s: %v", e.Attempts, e.Elapsed, e.LastErr)
+}
+
+// Unwrap returns LastErr, so errors.Is/errors.As see through a
+// RetryError to the underlying failure (e.g. a *APIError).
+func (e *RetryError) Unwrap() error {
+	return e.LastErr
+}
+
+func statusOf(resp *Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.Status
+}
+
+// recordHistory builds the HistoryEntry for one request attempt,
+// forwarding it to c.historyRecorder if one is installed, and always
+// returning it so callers can also assemble a per-call History for
+// RetryError.
+func (c *Client) recordHistory(req *Request, status int, err error, dur time.Duration) HistoryEntry {
+	entry := HistoryEntry{
+		Time:     time.Now(),
+		Method:   req.Method,
+		Path:     req.Path,
+		Status:   status,
+		Duration: dur,
+	}
+	if err != nil {
+		entry.Err = err.Error()
+	}
+	if c.historyRecorder != nil {
+		c.historyRecorder(entry)
+	}
+	return entry
+}
@@ -0,0 +1,21 @@
+//go:build http3
+
+package transport
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/quic-go/quic-go/http3"
+)
+
+// CreateHTTP3Transport creates a RoundTripper that speaks HTTP/3 over
+// QUIC, worth trying on lossy networks where TCP head-of-line blocking
+// materially slows large-image downloads. Experimental: built only with
+// -tags http3, since it pulls in quic-go, which this module otherwise
+// avoids depending on.
+func CreateHTTP3Transport() http.RoundTripper {
+	return &http3.RoundTripper{
+		TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS13},
+	}
+}
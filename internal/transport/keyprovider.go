@@ -0,0 +1,26 @@
+package transport
+
+import "context"
+
+// KeyProvider fetches an API key on demand, e.g. from Vault or AWS
+// Secrets Manager, so credentials can be rotated or revoked without
+// recreating the client. It is called once per request attempt, so a
+// provider that returns a freshly minted key after a 401 is picked up by
+// the automatic retry below without any action from the caller.
+type KeyProvider func(ctx context.Context) (string, error)
+
+// runKeyRefresh calls fn, and if provider is set and fn fails with an
+// authentication error, calls fn exactly once more to give the provider
+// a chance to return a refreshed key.
+func runKeyRefresh[T any](provider KeyProvider, fn func() (T, error)) (T, error) {
+	result, err := fn()
+	if err == nil || provider == nil {
+		return result, err
+	}
+
+	apiErr, ok := err.(*APIError)
+	if !ok || !apiErr.IsAuthError() {
+		return result, err
+	}
+	return fn()
+}
@@ -0,0 +1,86 @@
+package transport
+
+import "sync"
+
+// RotationPolicy controls how a KeyRotator selects among multiple API keys.
+type RotationPolicy int
+
+const (
+	// RoundRobin spreads every request across the key set in turn,
+	// regardless of outcome.
+	RoundRobin RotationPolicy = iota
+
+	// Failover sticks with the current key until it hits
+	// INSUFFICIENT_CREDITS or RATE_LIMIT_EXCEEDED, then advances to the
+	// next key and retries the request.
+	Failover
+)
+
+// KeyRotator cycles a client across a set of API keys.
+type KeyRotator struct {
+	mu     sync.Mutex
+	keys   []string
+	idx    int
+	policy RotationPolicy
+}
+
+// NewKeyRotator creates a KeyRotator over keys, selecting the next key
+// according to policy.
+func NewKeyRotator(keys []string, policy RotationPolicy) *KeyRotator {
+	return &KeyRotator{keys: keys, policy: policy}
+}
+
+// Current returns the key to use for the next request. Under RoundRobin
+// it advances the cursor on every call; under Failover it keeps returning
+// the same key until Advance is called.
+func (r *KeyRotator) Current() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := r.keys[r.idx]
+	if r.policy == RoundRobin {
+		r.idx = (r.idx + 1) % len(r.keys)
+	}
+	return key
+}
+
+// Advance moves to the next key and returns it, used when the current key
+// has failed over.
+func (r *KeyRotator) Advance() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.idx = (r.idx + 1) % len(r.keys)
+	return r.keys[r.idx]
+}
+
+// Len returns the number of keys in the rotation.
+func (r *KeyRotator) Len() int {
+	return len(r.keys)
+}
+
+// runFailover calls fn, and on INSUFFICIENT_CREDITS or RATE_LIMIT_EXCEEDED
+// advances keys and retries, up to one attempt per key.
+func runFailover[T any](keys *KeyRotator, fn func() (T, error)) (T, error) {
+	attempts := keys.Len()
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var zero T
+	var lastErr error
+	for i := 0; i < attempts; i++ {
+		result, err := fn()
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		apiErr, ok := err.(*APIError)
+		if !ok || !(apiErr.IsInsufficientFunds() || apiErr.IsRateLimit()) {
+			return zero, err
+		}
+		keys.Advance()
+	}
+	return zero, lastErr
+}
@@ -0,0 +1,102 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestKeyRotatorRoundRobin(t *testing.T) {
+	r := NewKeyRotator([]string{"a", "b", "c"}, RoundRobin)
+
+	got := []string{r.Current(), r.Current(), r.Current(), r.Current()}
+	want := []string{"a", "b", "c", "a"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Current() #%d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestKeyRotatorFailoverStaysOnCurrentKey(t *testing.T) {
+	r := NewKeyRotator([]string{"a", "b"}, Failover)
+
+	if got := r.Current(); got != "a" {
+		t.Fatalf("Current() = %q, want %q", got, "a")
+	}
+	if got := r.Current(); got != "a" {
+		t.Errorf("Current() after a second call = %q, want %q (Failover shouldn't advance on its own)", got, "a")
+	}
+}
+
+func TestKeyRotatorAdvanceWraps(t *testing.T) {
+	r := NewKeyRotator([]string{"a", "b"}, Failover)
+
+	if got := r.Advance(); got != "b" {
+		t.Errorf("Advance() = %q, want %q", got, "b")
+	}
+	if got := r.Advance(); got != "a" {
+		t.Errorf("Advance() after wrapping = %q, want %q", got, "a")
+	}
+}
+
+func TestRunFailoverAdvancesOnRateLimit(t *testing.T) {
+	keys := NewKeyRotator([]string{"a", "b"}, Failover)
+
+	attempts := 0
+	fn := func() (string, error) {
+		attempts++
+		if attempts == 1 {
+			return "", &APIError{StatusCode: http.StatusTooManyRequests}
+		}
+		return "ok", nil
+	}
+
+	result, err := runFailover(keys, fn)
+	if err != nil {
+		t.Fatalf("runFailover() error = %v", err)
+	}
+	if result != "ok" {
+		t.Errorf("runFailover() = %q, want %q", result, "ok")
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+}
+
+func TestRunFailoverStopsOnNonFailoverError(t *testing.T) {
+	keys := NewKeyRotator([]string{"a", "b"}, Failover)
+
+	wantErr := errors.New("not an API error")
+	attempts := 0
+	fn := func() (string, error) {
+		attempts++
+		return "", wantErr
+	}
+
+	_, err := runFailover(keys, fn)
+	if !errors.Is(err, wantErr) {
+		t.Errorf("runFailover() error = %v, want %v", err, wantErr)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (should not retry a non-failover error)", attempts)
+	}
+}
+
+func TestRunFailoverGivesUpAfterOneAttemptPerKey(t *testing.T) {
+	keys := NewKeyRotator([]string{"a", "b", "c"}, Failover)
+
+	attempts := 0
+	fn := func() (string, error) {
+		attempts++
+		return "", &APIError{StatusCode: http.StatusPaymentRequired}
+	}
+
+	_, err := runFailover(keys, fn)
+	if err == nil {
+		t.Fatal("runFailover() error = nil, want error")
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3 (one per key)", attempts)
+	}
+}
@@ -0,0 +1,58 @@
+package transport
+
+import (
+	"sync"
+	"time"
+)
+
+// Memoizer caches the result of a single idempotent, read-mostly call
+// (e.g. a future Models or Account lookup, once this SDK exposes those
+// endpoints) for ttl, so a caller that checks it on every request
+// doesn't round-trip the API each time. Only the Images service exists
+// today; Memoizer is a building block for read-mostly services added
+// later, not wired into anything yet.
+type Memoizer[T any] struct {
+	ttl time.Duration
+	fn  func() (T, error)
+
+	mu      sync.Mutex
+	value   T
+	fetched time.Time
+	valid   bool
+}
+
+// NewMemoizer returns a Memoizer that calls fn at most once per ttl,
+// serving the cached value to concurrent and subsequent callers in
+// between.
+func NewMemoizer[T any](ttl time.Duration, fn func() (T, error)) *Memoizer[T] {
+	return &Memoizer[T]{ttl: ttl, fn: fn}
+}
+
+// Get returns the cached value if it's younger than ttl, otherwise
+// calls fn, caching the result only on success.
+func (m *Memoizer[T]) Get() (T, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.valid && time.Since(m.fetched) < m.ttl {
+		return m.value, nil
+	}
+
+	value, err := m.fn()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	m.value = value
+	m.fetched = time.Now()
+	m.valid = true
+	return value, nil
+}
+
+// Invalidate discards the cached value, forcing the next Get to call fn.
+func (m *Memoizer[T]) Invalidate() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.valid = false
+}
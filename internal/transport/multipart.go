@@ -0,0 +1,78 @@
+package transport
+
+import (
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+)
+
+// MultipartFile is a reference image sent as a multipart/form-data file
+// part instead of base64-encoded inside the JSON body. See Request.Files
+// and Config.BinaryUploads.
+type MultipartFile struct {
+	// FieldName is the form field name the API expects the file under
+	// (e.g. "reference_image").
+	FieldName string
+
+	// FileName is an arbitrary name for the part; the Reve API does not
+	// use it, but multipart/form-data requires one.
+	FileName string
+
+	// ContentType, if set, is sent as the part's Content-Type.
+	ContentType string
+
+	// Reader supplies the image bytes. It is streamed directly into the
+	// request body without buffering the whole image in memory, so
+	// callers can pass e.g. an S3 object reader for large images.
+	Reader io.Reader
+}
+
+// buildMultipartBody streams req.Body (JSON-encoded into a "payload"
+// field) and req.Files into a multipart/form-data request body, via an
+// io.Pipe so no file is ever fully buffered in memory. It returns the
+// body reader and the Content-Type header value (including the
+// boundary) to send with it.
+func buildMultipartBody(req *Request) (io.Reader, string, error) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		err := writeMultipartParts(mw, req)
+		closeErr := mw.Close()
+		if err == nil {
+			err = closeErr
+		}
+		pw.CloseWithError(err)
+	}()
+
+	return pr, mw.FormDataContentType(), nil
+}
+
+func writeMultipartParts(mw *multipart.Writer, req *Request) error {
+	if req.Body != nil {
+		payload, err := json.Marshal(req.Body)
+		if err != nil {
+			return &RequestError{Op: "marshal", Err: err}
+		}
+		if err := mw.WriteField("payload", string(payload)); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range req.Files {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", `form-data; name="`+file.FieldName+`"; filename="`+file.FileName+`"`)
+		if file.ContentType != "" {
+			header.Set("Content-Type", file.ContentType)
+		}
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, file.Reader); err != nil {
+			return err
+		}
+	}
+	return nil
+}
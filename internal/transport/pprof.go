@@ -0,0 +1,24 @@
+package transport
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// withPprofLabels attaches profiler labels identifying which operation
+// (req.Path) and, if set, which quota partition (req.Partition, the
+// closest thing this SDK has to a tenant tag) a unit of work belongs
+// to, so heap and CPU profiles can attribute growth to a specific call
+// site instead of an undifferentiated "reve-go" blob.
+//
+// Labels propagate to every goroutine started while fn runs -- Do and
+// DoRaw wrap each attempt with this, so a hedge or retry goroutine
+// spawned from inside fn carries the same labels as the attempt that
+// launched it.
+func withPprofLabels(ctx context.Context, req *Request, fn func(ctx context.Context)) {
+	labels := []string{"operation", req.Path}
+	if req.Partition != "" {
+		labels = append(labels, "partition", req.Partition)
+	}
+	pprof.Do(ctx, pprof.Labels(labels...), fn)
+}
@@ -0,0 +1,26 @@
+package transport
+
+import "io"
+
+// progressReader wraps a response body, reporting cumulative bytes read
+// to onBytes as the caller drains it. total is the Content-Length
+// reported by the server, or 0 if unknown.
+type progressReader struct {
+	r       io.ReadCloser
+	total   int64
+	read    int64
+	onBytes func(read, total int64)
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	if n > 0 {
+		p.read += int64(n)
+		p.onBytes(p.read, p.total)
+	}
+	return n, err
+}
+
+func (p *progressReader) Close() error {
+	return p.r.Close()
+}
@@ -2,25 +2,87 @@ package transport
 
 import (
 	"context"
+	"errors"
 	"math"
 	"math/rand/v2"
 	"net/http"
 	"time"
 )
 
-// Retrier handles retry logic with exponential backoff.
+// BackoffPolicy computes how long to wait before retry attempt (1-indexed;
+// Do and DoRaw never call it with 0). Implementations may ignore attempt
+// and return a fixed delay, or consult their own state for policies like
+// decorrelated jitter. See ExponentialBackoff for the default.
+type BackoffPolicy interface {
+	Backoff(attempt int) time.Duration
+}
+
+// ExponentialBackoff is the default BackoffPolicy: delay doubles with
+// each attempt starting from Min, capped at Max, with +/-25% jitter to
+// avoid synchronized retries across clients.
+type ExponentialBackoff struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// Backoff implements BackoffPolicy.
+func (b ExponentialBackoff) Backoff(attempt int) time.Duration {
+	backoff := float64(b.Min) * math.Pow(2, float64(attempt-1))
+	jitter := backoff * 0.25 * (rand.Float64()*2 - 1)
+	backoff += jitter
+
+	if backoff > float64(b.Max) {
+		backoff = float64(b.Max)
+	}
+	return time.Duration(backoff)
+}
+
+// Retrier handles retry logic with a pluggable backoff policy.
 type Retrier struct {
 	maxRetries int
-	minWait    time.Duration
-	maxWait    time.Duration
+	policy     BackoffPolicy
+
+	// budget, if set, is shared across every Retrier derived from the
+	// same Client (see WithBudget and retrierFor), throttling how
+	// much of the client's total traffic may be retries.
+	budget *RetryBudget
 }
 
-// NewRetrier creates a new retrier.
+// NewRetrier creates a new retrier using the default ExponentialBackoff
+// policy bounded by minWait and maxWait.
 func NewRetrier(maxRetries int, minWait, maxWait time.Duration) *Retrier {
+	return NewRetrierWithPolicy(maxRetries, ExponentialBackoff{Min: minWait, Max: maxWait})
+}
+
+// NewRetrierWithPolicy creates a new retrier using a custom BackoffPolicy,
+// for callers that need something other than capped exponential backoff
+// (e.g. a fixed delay, or decorrelated jitter).
+func NewRetrierWithPolicy(maxRetries int, policy BackoffPolicy) *Retrier {
+	return &Retrier{
+		maxRetries: maxRetries,
+		policy:     policy,
+	}
+}
+
+// WithMaxRetries returns a copy of the retrier with maxRetries overridden,
+// keeping the same backoff policy and retry budget. Used for per-request
+// retry overrides.
+func (r *Retrier) WithMaxRetries(maxRetries int) *Retrier {
 	return &Retrier{
 		maxRetries: maxRetries,
-		minWait:    minWait,
-		maxWait:    maxWait,
+		policy:     r.policy,
+		budget:     r.budget,
+	}
+}
+
+// WithBudget returns a copy of the retrier sharing budget across every
+// request made through it (and every Retrier later derived from the
+// copy via WithMaxRetries).
+func (r *Retrier) WithBudget(budget *RetryBudget) *Retrier {
+	return &Retrier{
+		maxRetries: r.maxRetries,
+		policy:     r.policy,
+		budget:     budget,
 	}
 }
 
@@ -28,8 +90,15 @@ func NewRetrier(maxRetries int, minWait, maxWait time.Duration) *Retrier {
 func (r *Retrier) Do(ctx context.Context, fn func() (*Response, error)) (*Response, error) {
 	var lastErr error
 
+	if r.budget != nil {
+		r.budget.deposit()
+	}
+
 	for attempt := 0; attempt <= r.maxRetries; attempt++ {
 		if attempt > 0 {
+			if r.budget != nil && !r.budget.withdraw() {
+				return nil, &RetryBudgetExceededError{LastErr: lastErr}
+			}
 			if err := r.wait(ctx, attempt); err != nil {
 				return nil, err
 			}
@@ -58,8 +127,15 @@ func (r *Retrier) Do(ctx context.Context, fn func() (*Response, error)) (*Respon
 func (r *Retrier) DoRaw(ctx context.Context, fn func() (*RawResponse, error)) (*RawResponse, error) {
 	var lastErr error
 
+	if r.budget != nil {
+		r.budget.deposit()
+	}
+
 	for attempt := 0; attempt <= r.maxRetries; attempt++ {
 		if attempt > 0 {
+			if r.budget != nil && !r.budget.withdraw() {
+				return nil, &RetryBudgetExceededError{LastErr: lastErr}
+			}
 			if err := r.wait(ctx, attempt); err != nil {
 				return nil, err
 			}
@@ -85,7 +161,7 @@ func (r *Retrier) DoRaw(ctx context.Context, fn func() (*RawResponse, error)) (*
 }
 
 func (r *Retrier) wait(ctx context.Context, attempt int) error {
-	backoff := r.calculateBackoff(attempt)
+	backoff := r.policy.Backoff(attempt)
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -94,22 +170,15 @@ func (r *Retrier) wait(ctx context.Context, attempt int) error {
 	}
 }
 
-func (r *Retrier) calculateBackoff(attempt int) time.Duration {
-	backoff := float64(r.minWait) * math.Pow(2, float64(attempt-1))
-	jitter := backoff * 0.25 * (rand.Float64()*2 - 1)
-	backoff += jitter
-
-	if backoff > float64(r.maxWait) {
-		backoff = float64(r.maxWait)
-	}
-
-	return time.Duration(backoff)
-}
-
 func (r *Retrier) shouldRetry(err error) bool {
-	if apiErr, ok := err.(*APIError); ok {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
 		return apiErr.Retryable()
 	}
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.Retryable()
+	}
 	return false
 }
 
@@ -0,0 +1,51 @@
+package transport
+
+import "sync"
+
+// RetryBudget caps the fraction of traffic that may be retries, shared
+// across every request a Client makes, so a client hammering an
+// unhealthy API with many concurrent failing requests doesn't multiply
+// its own load by MaxRetries on top of it. It's a token bucket: every
+// request deposits Ratio tokens (capped at Max), and every retry
+// attempt withdraws one; once the bucket is empty, further retries are
+// denied and a *RetryBudgetExceededError wrapping the most recent error
+// is returned immediately instead.
+//
+// A nil *RetryBudget (the default) imposes no limit — every retriable
+// error is retried up to the configured MaxRetries, as before.
+type RetryBudget struct {
+	max   float64
+	ratio float64
+
+	mu     sync.Mutex
+	tokens float64
+}
+
+// NewRetryBudget creates a RetryBudget starting full, holding at most
+// max tokens and crediting ratio tokens per request. A typical ratio of
+// 0.1 allows roughly one retry for every ten requests sustained over
+// time, while still tolerating short bursts up to max.
+func NewRetryBudget(max, ratio float64) *RetryBudget {
+	return &RetryBudget{max: max, ratio: ratio, tokens: max}
+}
+
+// deposit credits one request's worth of tokens.
+func (b *RetryBudget) deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens += b.ratio
+	if b.tokens > b.max {
+		b.tokens = b.max
+	}
+}
+
+// withdraw reports whether a retry may proceed, consuming a token if so.
+func (b *RetryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,58 @@
+package transport
+
+import (
+	"context"
+	"io"
+)
+
+// StreamMeta carries the metadata available from response headers before
+// any of the body has been read.
+type StreamMeta struct {
+	ContentType      string
+	Version          string
+	ContentViolation bool
+	RequestID        string
+	CreditsUsed      int
+	CreditsRemaining int
+}
+
+// DoStream executes req and returns the response body unread, so callers
+// can io.Copy it directly to a destination (e.g. an http.ResponseWriter)
+// with no intermediate buffering. The caller owns the returned
+// io.ReadCloser and must Close it.
+//
+// Streaming requests are not retried: once the connection is established
+// there is no way to safely re-attempt a partially consumed stream.
+func (c *Client) DoStream(ctx context.Context, req *Request) (io.ReadCloser, *StreamMeta, error) {
+	applyOverrides(ctx, req)
+	applyTraceparent(ctx, req)
+
+	httpReq, err := c.buildRequest(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.log("Request (stream): %s %s", httpReq.Method, httpReq.URL)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, nil, &RequestError{Op: "http", Err: err}
+	}
+
+	if errCode := resp.Header.Get("X-Reve-Error-Code"); errCode != "" {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, nil, ParseError(resp, body)
+	}
+
+	meta := &StreamMeta{
+		ContentType:      resp.Header.Get("Content-Type"),
+		Version:          resp.Header.Get("X-Reve-Version"),
+		ContentViolation: resp.Header.Get("X-Reve-Content-Violation") == "true",
+		RequestID:        resp.Header.Get("X-Reve-Request-Id"),
+		CreditsUsed:      parseIntHeader(resp, "X-Reve-Credits-Used"),
+		CreditsRemaining: parseIntHeader(resp, "X-Reve-Credits-Remaining"),
+	}
+
+	return resp.Body, meta, nil
+}
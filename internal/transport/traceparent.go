@@ -0,0 +1,47 @@
+package transport
+
+import (
+	"context"
+	"regexp"
+)
+
+// traceparentPattern matches a W3C Trace Context traceparent header:
+// version-traceid-parentid-flags, e.g.
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01.
+var traceparentPattern = regexp.MustCompile(`^[0-9a-f]{2}-([0-9a-f]{32})-[0-9a-f]{16}-[0-9a-f]{2}$`)
+
+type traceparentKey struct{}
+
+// ContextWithTraceparent returns a context carrying a W3C Trace Context
+// traceparent value, propagated on every request made with it: as the
+// "traceparent" header, and -- when the request doesn't already set its
+// own Breadcrumb -- as the breadcrumb, so Reve's server-side logs can
+// be correlated with the caller's own traces without per-call wiring.
+// See reve.WithTraceparent.
+func ContextWithTraceparent(ctx context.Context, traceparent string) context.Context {
+	return context.WithValue(ctx, traceparentKey{}, traceparent)
+}
+
+func traceIDFrom(traceparent string) string {
+	m := traceparentPattern.FindStringSubmatch(traceparent)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// applyTraceparent sets req's traceparent header from ctx, if any, and
+// defaults Breadcrumb to its trace ID when req has none of its own.
+func applyTraceparent(ctx context.Context, req *Request) {
+	tp, _ := ctx.Value(traceparentKey{}).(string)
+	if tp == "" {
+		return
+	}
+	if req.Headers == nil {
+		req.Headers = make(map[string]string)
+	}
+	req.Headers["traceparent"] = tp
+	if req.Breadcrumb == "" {
+		req.Breadcrumb = traceIDFrom(tp)
+	}
+}
@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	_ "image/jpeg" // register jpeg.DecodeConfig
+	_ "image/png"  // register png.DecodeConfig
+)
+
+// ErrImageResolutionTooLarge is returned when a reference image decodes
+// to more than MaxInputMegapixels (or the limit passed to
+// ValidateReferenceImageLimits).
+var ErrImageResolutionTooLarge = errors.New("reference image resolution exceeds the maximum allowed megapixels")
+
+// MaxInputMegapixels is the default resolution limit used by
+// ValidateReferenceImage. Reve does not publish an official input
+// resolution limit, so this is a conservative cutoff meant to catch an
+// oversized input (e.g. an unresized camera photo) before it fails
+// server-side with an opaque error; callers with different needs can
+// use ValidateReferenceImageLimits directly.
+const MaxInputMegapixels = 25.0
+
+// ValidateReferenceImageLimits is ValidateReferenceImage with explicit
+// decoded-size and resolution limits, for callers who need different
+// cutoffs than MaxReferenceImageBytes and MaxInputMegapixels.
+func ValidateReferenceImageLimits(img string, maxBytes int, maxMegapixels float64) error {
+	if img == "" {
+		return ErrEmptyReferenceImage
+	}
+	decoded, err := decodeReferenceImage(img, maxBytes)
+	if err != nil {
+		return err
+	}
+	width, height, err := decodeImageDimensions(decoded)
+	if err != nil {
+		return err
+	}
+	if megapixels := float64(width*height) / 1_000_000; megapixels > maxMegapixels {
+		return ErrImageResolutionTooLarge
+	}
+	return nil
+}
+
+// decodeImageDimensions reads the pixel width and height from PNG,
+// JPEG, or WebP data without decoding the full image. data is assumed
+// to already have a valid signature (see hasImageSignature).
+func decodeImageDimensions(data []byte) (width, height int, err error) {
+	if bytes.HasPrefix(data, []byte{0x89, 'P', 'N', 'G'}) || bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}) {
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return 0, 0, ErrInvalidImageData
+		}
+		return cfg.Width, cfg.Height, nil
+	}
+	return decodeWebPDimensions(data)
+}
+
+// decodeWebPDimensions reads width and height from a WebP file's RIFF
+// container. Go's standard library has no WebP decoder, so this reads
+// just enough of the lossy (VP8), lossless (VP8L), or extended (VP8X)
+// chunk header to get the canvas size -- see the RIFF container and
+// "Simple File Format" sections of the WebP container spec.
+func decodeWebPDimensions(data []byte) (width, height int, err error) {
+	if len(data) < 30 {
+		return 0, 0, ErrInvalidImageData
+	}
+	payload := data[20:]
+	switch string(data[12:16]) {
+	case "VP8X":
+		// 1 byte flags, 3 bytes reserved, then 3-byte LE (width-1) and
+		// 3-byte LE (height-1).
+		if len(payload) < 10 {
+			return 0, 0, ErrInvalidImageData
+		}
+		width = int(uint24LE(payload[4:7])) + 1
+		height = int(uint24LE(payload[7:10])) + 1
+		return width, height, nil
+	case "VP8L":
+		// Signature byte 0x2f, then a 32-bit LE word packing
+		// 14-bit (width-1) and 14-bit (height-1).
+		if len(payload) < 5 || payload[0] != 0x2f {
+			return 0, 0, ErrInvalidImageData
+		}
+		bits := binary.LittleEndian.Uint32(payload[1:5])
+		width = int(bits&0x3FFF) + 1
+		height = int((bits>>14)&0x3FFF) + 1
+		return width, height, nil
+	case "VP8 ":
+		// 3-byte frame tag, 3-byte sync code (0x9d 0x01 0x2a), then
+		// two 16-bit LE fields holding a 14-bit size and a 2-bit scale.
+		if len(payload) < 10 || payload[3] != 0x9d || payload[4] != 0x01 || payload[5] != 0x2a {
+			return 0, 0, ErrInvalidImageData
+		}
+		width = int(binary.LittleEndian.Uint16(payload[6:8]) & 0x3FFF)
+		height = int(binary.LittleEndian.Uint16(payload[8:10]) & 0x3FFF)
+		return width, height, nil
+	default:
+		return 0, 0, ErrInvalidImageData
+	}
+}
+
+func uint24LE(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+}
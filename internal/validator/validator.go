@@ -1,7 +1,12 @@
 // Package validator provides request validation.
 package validator
 
-import "errors"
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"unicode/utf8"
+)
 
 // Validation errors.
 var (
@@ -14,6 +19,17 @@ var (
 	ErrInvalidAspectRatio     = errors.New("invalid aspect ratio")
 	ErrInvalidUpscaleFactor   = errors.New("upscale factor must be 2, 3, or 4")
 	ErrInvalidScaling         = errors.New("test time scaling must be 1-15")
+	ErrInvalidNumImages       = errors.New("num images must be 1-4")
+
+	// ErrInvalidImageData is returned when a reference image isn't
+	// well-formed base64, or decodes to bytes that don't start with a
+	// PNG, JPEG, or WebP signature.
+	ErrInvalidImageData = errors.New("reference image is not valid base64-encoded PNG, JPEG, or WebP data")
+
+	// ErrImageTooLarge is returned when a reference image's decoded size
+	// exceeds MaxReferenceImageBytes (or the limit passed to
+	// ValidateReferenceImageSize).
+	ErrImageTooLarge = errors.New("reference image exceeds the maximum allowed size")
 )
 
 // Constants
@@ -22,6 +38,15 @@ const (
 	MaxReferenceImages = 6
 	MinScaling         = 1.0
 	MaxScaling         = 15.0
+	MaxNumImages       = 4
+
+	// MaxReferenceImageBytes is the default decoded size limit used by
+	// ValidateReferenceImage. Reve does not publish an official per-image
+	// limit, so this is a conservative cutoff meant to catch an
+	// obviously-wrong payload (the wrong file, an uncompressed bitmap)
+	// before spending a round trip on it; callers with different needs
+	// can use ValidateReferenceImageSize directly.
+	MaxReferenceImageBytes = 20 * 1024 * 1024
 )
 
 // ValidatePrompt validates a prompt string.
@@ -29,7 +54,7 @@ func ValidatePrompt(prompt string) error {
 	if prompt == "" {
 		return ErrEmptyPrompt
 	}
-	if len(prompt) > MaxPromptLength {
+	if PromptLength(prompt) > MaxPromptLength {
 		return ErrPromptTooLong
 	}
 	return nil
@@ -40,18 +65,83 @@ func ValidateInstruction(instruction string) error {
 	if instruction == "" {
 		return ErrEmptyInstruction
 	}
-	if len(instruction) > MaxPromptLength {
+	if PromptLength(instruction) > MaxPromptLength {
 		return ErrPromptTooLong
 	}
 	return nil
 }
 
-// ValidateReferenceImage validates a single reference image.
+// PromptLength returns the length of prompt the way MaxPromptLength is
+// defined against: UTF-8 runes, not bytes. len(prompt) overcounts every
+// multi-byte character, so a CJK or emoji-heavy prompt well under the
+// API's actual 2560-character limit could otherwise be rejected early
+// (or, for a prompt mixing ASCII and multi-byte runes, still slip past
+// a byte-counted check that happens to land short).
+func PromptLength(prompt string) int {
+	return utf8.RuneCountInString(prompt)
+}
+
+// TruncatePrompt returns prompt cut down to at most n runes, leaving it
+// unchanged if it's already within n. Unlike slicing prompt[:n], this
+// never splits a multi-byte rune.
+func TruncatePrompt(prompt string, n int) string {
+	if PromptLength(prompt) <= n {
+		return prompt
+	}
+	runes := []rune(prompt)
+	return string(runes[:n])
+}
+
+// ValidateReferenceImage validates a single reference image: that it's
+// present, is well-formed base64, decodes under MaxReferenceImageBytes,
+// starts with a PNG, JPEG, or WebP signature, and has a resolution
+// under MaxInputMegapixels.
 func ValidateReferenceImage(image string) error {
+	return ValidateReferenceImageLimits(image, MaxReferenceImageBytes, MaxInputMegapixels)
+}
+
+// ValidateReferenceImageSize is ValidateReferenceImage with an explicit
+// decoded-size limit in bytes, for callers who need a different cutoff
+// than MaxReferenceImageBytes. It does not check resolution; see
+// ValidateReferenceImageLimits for that.
+func ValidateReferenceImageSize(image string, maxBytes int) error {
+	_, err := decodeReferenceImage(image, maxBytes)
+	return err
+}
+
+// decodeReferenceImage base64-decodes image, checking it's non-empty,
+// well-formed, under maxBytes once decoded, and starts with a PNG,
+// JPEG, or WebP signature.
+func decodeReferenceImage(image string, maxBytes int) ([]byte, error) {
 	if image == "" {
-		return ErrEmptyReferenceImage
+		return nil, ErrEmptyReferenceImage
+	}
+	decoded, err := base64.StdEncoding.DecodeString(image)
+	if err != nil {
+		return nil, ErrInvalidImageData
+	}
+	if len(decoded) > maxBytes {
+		return nil, ErrImageTooLarge
+	}
+	if !hasImageSignature(decoded) {
+		return nil, ErrInvalidImageData
+	}
+	return decoded, nil
+}
+
+// hasImageSignature reports whether data starts with a PNG, JPEG, or
+// WebP magic number.
+func hasImageSignature(data []byte) bool {
+	switch {
+	case bytes.HasPrefix(data, []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a}):
+		return true
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return true
+	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return true
+	default:
+		return false
 	}
-	return nil
 }
 
 // ValidateReferenceImages validates multiple reference images.
@@ -62,6 +152,11 @@ func ValidateReferenceImages(images []string) error {
 	if len(images) > MaxReferenceImages {
 		return ErrTooManyReferenceImages
 	}
+	for _, image := range images {
+		if err := ValidateReferenceImage(image); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -100,3 +195,14 @@ func ValidateScaling(scaling float64) error {
 	}
 	return nil
 }
+
+// ValidateNumImages validates a requested image count.
+func ValidateNumImages(n int) error {
+	if n == 0 {
+		return nil
+	}
+	if n < 1 || n > MaxNumImages {
+		return ErrInvalidNumImages
+	}
+	return nil
+}
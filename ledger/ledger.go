@@ -0,0 +1,102 @@
+// Package ledger locally tracks credits spent per request and
+// reconciles that record against itself, to catch double-billing when
+// a POST is retried and both the original attempt and the retry are
+// charged.
+//
+// The Reve API exposes no account/usage endpoint this SDK can compare
+// against, so Reconcile can only verify internal consistency of what
+// the SDK itself observed -- not settle a dispute with the server's
+// own books. See Reconcile for what it can and can't catch.
+package ledger
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/shamspias/reve-go/image"
+)
+
+// Entry is one billed request, recorded locally.
+type Entry struct {
+	RequestID   string
+	CreditsUsed int
+	RecordedAt  time.Time
+}
+
+// Recorder accumulates Entry values for later reconciliation. The zero
+// value is not ready to use; create one with NewRecorder.
+type Recorder struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewRecorder creates an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Record appends entry. Safe for concurrent use.
+func (r *Recorder) Record(entry Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry)
+}
+
+// Entries returns a defensive copy of every entry recorded so far.
+func (r *Recorder) Entries() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Entry(nil), r.entries...)
+}
+
+// Period bounds a reconciliation pass to entries recorded in [Start, End).
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (p Period) contains(t time.Time) bool {
+	return !t.Before(p.Start) && t.Before(p.End)
+}
+
+// Report summarizes a Reconcile pass.
+type Report struct {
+	// TotalCredits is the sum of CreditsUsed across every entry in period.
+	TotalCredits int
+
+	// Duplicates holds entries that share a RequestID with an earlier
+	// entry in period -- the signature of a retried POST that was
+	// billed more than once.
+	Duplicates []Entry
+}
+
+// Reconcile checks rec's entries recorded within period for duplicate
+// RequestIDs and sums total credits spent.
+//
+// svc is accepted for forward compatibility with a future Reve account
+// usage endpoint, which would let Reconcile cross-check rec against the
+// server's own billing record; no such endpoint exists today, so svc is
+// currently unused and Reconcile only catches discrepancies already
+// visible in rec. A billing error with no corresponding local entry
+// cannot be detected this way.
+func Reconcile(ctx context.Context, svc *image.Service, rec *Recorder, period Period) (*Report, error) {
+	_ = ctx
+	_ = svc
+
+	seen := make(map[string]bool)
+	report := &Report{}
+	for _, entry := range rec.Entries() {
+		if !period.contains(entry.RecordedAt) {
+			continue
+		}
+		report.TotalCredits += entry.CreditsUsed
+		if entry.RequestID != "" {
+			if seen[entry.RequestID] {
+				report.Duplicates = append(report.Duplicates, entry)
+			}
+			seen[entry.RequestID] = true
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,157 @@
+// Package metadata embeds generation provenance — prompt, model,
+// request ID, seed, creation time — directly into a saved image, so a
+// generated asset carries its own audit trail independent of whatever
+// database row produced it.
+//
+// PNG images get the metadata as a tEXt chunk; JPEG images get it as a
+// COM (comment) segment. Both are plain JSON rather than full EXIF/XMP
+// schemas, since every commonly available image viewer and tool reads
+// tEXt/COM text, and round-tripping real EXIF IFDs needs a dependency
+// this module doesn't otherwise carry.
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"hash/crc32"
+	"time"
+)
+
+// textKeyword is the PNG tEXt chunk keyword and JPEG COM segment prefix
+// metadata is stored under.
+const textKeyword = "reve:metadata"
+
+// Metadata is the generation provenance embedded into a saved image.
+type Metadata struct {
+	// Prompt is the text prompt or edit instruction that produced the image.
+	Prompt string
+
+	// Model is the model version used (see types.ModelVersion).
+	Model string
+
+	// RequestID identifies the generation request.
+	RequestID string
+
+	// CreatedAt is when the image was generated.
+	CreatedAt time.Time
+
+	// Seed is the generation's random seed. See types.Result.Seed.
+	Seed int64
+}
+
+// wire is Metadata's embedded JSON representation.
+type wire struct {
+	Prompt    string `json:"prompt"`
+	Model     string `json:"model"`
+	RequestID string `json:"request_id"`
+	CreatedAt string `json:"created_at"`
+	Seed      int64  `json:"seed"`
+}
+
+var (
+	pngSignature = []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}
+	jpegSOI      = []byte{0xFF, 0xD8}
+)
+
+// ErrUnsupportedFormat is returned by Embed when data isn't a PNG or
+// JPEG image.
+var ErrUnsupportedFormat = errors.New("metadata: unsupported image format")
+
+// Embed returns a copy of data with meta embedded as provenance,
+// detecting PNG or JPEG by magic bytes.
+func Embed(data []byte, meta Metadata) ([]byte, error) {
+	payload, err := json.Marshal(wire{
+		Prompt:    meta.Prompt,
+		Model:     meta.Model,
+		RequestID: meta.RequestID,
+		CreatedAt: meta.CreatedAt.UTC().Format(time.RFC3339),
+		Seed:      meta.Seed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return EmbedRaw(data, textKeyword, payload)
+}
+
+// EmbedRaw returns a copy of data with payload embedded under keyword,
+// detecting PNG or JPEG by magic bytes. It is the lower-level operation
+// Embed builds on; other packages needing to carry their own tagged
+// payload in an image (see package provenance) can call it directly
+// instead of going through the Metadata JSON shape.
+func EmbedRaw(data []byte, keyword string, payload []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, pngSignature):
+		return embedPNG(data, keyword, payload)
+	case bytes.HasPrefix(data, jpegSOI):
+		return embedJPEG(data, keyword, payload)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// embedPNG inserts a tEXt chunk carrying payload immediately before the
+// PNG's IEND chunk.
+func embedPNG(data []byte, keyword string, payload []byte) ([]byte, error) {
+	offset := len(pngSignature)
+	for offset+8 <= len(data) {
+		length := binary.BigEndian.Uint32(data[offset : offset+4])
+		typ := string(data[offset+4 : offset+8])
+		chunkEnd := offset + 8 + int(length) + 4
+		if chunkEnd > len(data) {
+			return nil, errors.New("metadata: truncated PNG chunk")
+		}
+
+		if typ == "IEND" {
+			chunk := buildTextChunk(keyword, payload)
+			out := make([]byte, 0, len(data)+len(chunk))
+			out = append(out, data[:offset]...)
+			out = append(out, chunk...)
+			out = append(out, data[offset:]...)
+			return out, nil
+		}
+		offset = chunkEnd
+	}
+	return nil, errors.New("metadata: IEND chunk not found")
+}
+
+// buildTextChunk builds a complete PNG tEXt chunk (length, type, data,
+// CRC) carrying text under keyword.
+func buildTextChunk(keyword string, text []byte) []byte {
+	body := append([]byte(keyword+"\x00"), text...)
+
+	chunk := make([]byte, 0, 12+len(body))
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(body)))
+	chunk = append(chunk, length...)
+	chunk = append(chunk, []byte("tEXt")...)
+	chunk = append(chunk, body...)
+
+	crc := crc32.ChecksumIEEE(append([]byte("tEXt"), body...))
+	crcBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(crcBytes, crc)
+	return append(chunk, crcBytes...)
+}
+
+// embedJPEG inserts a COM segment carrying payload immediately after
+// the JPEG's SOI marker.
+func embedJPEG(data []byte, keyword string, payload []byte) ([]byte, error) {
+	body := append([]byte(keyword+"\x00"), payload...)
+	segmentLength := len(body) + 2 // includes the two length bytes themselves
+	if segmentLength > 0xFFFF {
+		return nil, errors.New("metadata: payload too large for a JPEG COM segment")
+	}
+
+	segment := make([]byte, 4+len(body))
+	segment[0] = 0xFF
+	segment[1] = 0xFE
+	binary.BigEndian.PutUint16(segment[2:4], uint16(segmentLength))
+	copy(segment[4:], body)
+
+	out := make([]byte, 0, len(data)+len(segment))
+	out = append(out, data[:len(jpegSOI)]...)
+	out = append(out, segment...)
+	out = append(out, data[len(jpegSOI):]...)
+	return out, nil
+}
@@ -0,0 +1,30 @@
+// Package migration converts v1 error values into their v2 shape, so
+// code can start handling errors through github.com/shamspias/reve-go/v2/errors
+// before fully switching over to the v2 client. See the v2 package doc
+// for what v2 currently covers (promoted error types only) and what's
+// still planned.
+package migration
+
+import (
+	v2errors "github.com/shamspias/reve-go/v2/errors"
+
+	"github.com/shamspias/reve-go/internal/transport"
+)
+
+// APIError converts a v1 *transport.APIError into a v2 *errors.APIError
+// with the same Code, Message, StatusCode, RequestID, and RetryAfter.
+// RequestBody and Params are copied as-is.
+func APIError(err *transport.APIError) *v2errors.APIError {
+	if err == nil {
+		return nil
+	}
+	return &v2errors.APIError{
+		Code:        v2errors.ErrorCode(err.Code),
+		Message:     err.Message,
+		Params:      err.Params,
+		StatusCode:  err.StatusCode,
+		RequestID:   err.RequestID,
+		RetryAfter:  err.RetryAfter,
+		RequestBody: err.RequestBody,
+	}
+}
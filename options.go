@@ -1,10 +1,15 @@
 package reve
 
 import (
+	"crypto/x509"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/shamspias/reve-go/internal/transport"
+	"github.com/shamspias/reve-go/recorder"
+	"github.com/shamspias/reve-go/simulate"
 )
 
 // Option is a functional option for Client configuration.
@@ -56,6 +61,40 @@ func WithNoRetry() Option {
 	}
 }
 
+// WithBackoffPolicy overrides the default capped-exponential-with-jitter
+// wait between retries with a custom transport.BackoffPolicy, for
+// callers that need a different shape (fixed delay, decorrelated
+// jitter, a policy tuned from observed upstream behavior). RetryMinWait
+// and RetryMaxWait from WithRetry are ignored once this is set.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithBackoffPolicy(transport.ExponentialBackoff{
+//		Min: 500 * time.Millisecond,
+//		Max: 10 * time.Second,
+//	}))
+func WithBackoffPolicy(policy transport.BackoffPolicy) Option {
+	return func(c *Config) {
+		c.BackoffPolicy = policy
+	}
+}
+
+// WithRetryBudget caps the fraction of the client's traffic that may be
+// retries, shared across every request made through the client, so a
+// burst of failures doesn't multiply load on an already-unhealthy API
+// by MaxRetries on top of it. max is the largest burst of retries
+// allowed at once; ratio is how many retry tokens each request earns
+// (0.1 permits roughly one retry per ten requests, sustained).
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithRetryBudget(10, 0.1))
+func WithRetryBudget(max, ratio float64) Option {
+	return func(c *Config) {
+		c.RetryBudget = transport.NewRetryBudget(max, ratio)
+	}
+}
+
 // WithUserAgent sets a custom User-Agent header.
 //
 // Example:
@@ -92,6 +131,353 @@ func WithLogger(logger func(format string, args ...any)) Option {
 	}
 }
 
+// WithSlogLogger enables structured request logging via a *slog.Logger,
+// emitting method, path, status, request_id, attempt, duration, and
+// credits_used fields per attempt. The Authorization header (API key) is
+// never included in the logged fields.
+//
+// Example:
+//
+//	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+//	client := reve.NewClient(apiKey, reve.WithSlogLogger(logger))
+func WithSlogLogger(logger *slog.Logger) Option {
+	return func(c *Config) {
+		c.SlogLogger = logger
+	}
+}
+
+// WithSimulation replaces the client's transport with a local mock that
+// serves deterministic placeholder images after profile's configured
+// latency, with profile's configured failure rate and credit cost, so
+// full application flows (including budgets and retries) can be
+// exercised in staging with zero spend.
+//
+// Example:
+//
+//	client := reve.NewClient("unused", reve.WithSimulation(reve.SimProfile{
+//		Latency:         200 * time.Millisecond,
+//		FailureRate:     0.1,
+//		CreditCost:      1,
+//		StartingCredits: 100,
+//	}))
+func WithSimulation(profile SimProfile) Option {
+	return func(c *Config) {
+		c.Transport = simulate.NewTransport(profile)
+	}
+}
+
+// WithContentFilter runs filter against every prompt (Create's Prompt,
+// Edit's Instruction, Remix's Prompt) before it is sent, returning the
+// filter's error instead of making the request when it blocks. Lets
+// enterprise users catch obviously violating prompts locally before
+// spending credits and incurring a CONTENT_POLICY_VIOLATION strike. See
+// image.NewKeywordScreener for a bundled keyword/regex screener.
+//
+// Example:
+//
+//	filter, _ := image.NewKeywordScreener([]string{`\bweapon\b`})
+//	client := reve.NewClient(apiKey, reve.WithContentFilter(filter))
+func WithContentFilter(filter ContentFilter) Option {
+	return func(c *Config) {
+		c.contentFilter = filter
+	}
+}
+
+// WithKeyProvider configures a dynamic credential source instead of a
+// static API key, so keys fetched from Vault, AWS Secrets Manager, or
+// similar can be rotated without recreating the client. The provider is
+// called once per request attempt; if a request fails with an
+// authentication error, it is called a second time so a freshly rotated
+// key gets one chance to succeed.
+//
+// Example:
+//
+//	client := reve.NewClient("", reve.WithKeyProvider(func(ctx context.Context) (string, error) {
+//		return secretsManager.GetCurrentKey(ctx)
+//	}))
+func WithKeyProvider(provider KeyProvider) Option {
+	return func(c *Config) {
+		c.keyProvider = provider
+	}
+}
+
+// WithFallback installs a degraded-result handler run when Create, Edit,
+// or Remix fail after exhausting retries, e.g. to serve a cached or
+// placeholder image instead of erroring during a Reve outage. fallback
+// receives the failing operation's params (*image.CreateParams,
+// *image.EditParams, or *image.RemixParams).
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithFallback(
+//		func(ctx context.Context, params any) (*types.Result, error) {
+//			img, err := placeholder.Generate(placeholder.Options{Label: "temporarily unavailable"})
+//			if err != nil {
+//				return nil, err
+//			}
+//			return &types.Result{Image: base64.StdEncoding.EncodeToString(img)}, nil
+//		},
+//	))
+func WithFallback(fallback Fallback) Option {
+	return func(c *Config) {
+		c.fallback = fallback
+	}
+}
+
+// WithCache installs a Cache consulted before, and populated after,
+// every Create, Edit, or Remix call, keyed by a canonical hash of the
+// request's prompt (or instruction), seed, model version, and aspect
+// ratio. Lets a batch script re-run without re-spending credits on
+// requests it already made. See package cache for bundled MemoryLRU and
+// FileCache implementations.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithCache(cache.NewMemoryLRU(256)))
+func WithCache(c Cache) Option {
+	return func(cfg *Config) {
+		cfg.cache = c
+	}
+}
+
+// WithFeatureFlags installs a FlagProvider consulted before every
+// Create, Edit, and Remix call, so operators can disable an operation
+// or force fast-model routing at runtime (e.g. during an incident)
+// through their existing flag system, without redeploying callers.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithFeatureFlags(myFlagProvider))
+func WithFeatureFlags(flags FlagProvider) Option {
+	return func(c *Config) {
+		c.flags = flags
+	}
+}
+
+// WithCapabilityProvider installs a CapabilityProvider consulted before
+// every Create, Edit, and Remix call, to validate AspectRatio and
+// NumImages against what the requested model version actually
+// supports, rather than relying solely on the SDK's hardcoded
+// defaults (which can drift as new versions ship).
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithCapabilityProvider(myProvider))
+func WithCapabilityProvider(provider CapabilityProvider) Option {
+	return func(c *Config) {
+		c.capabilities = provider
+	}
+}
+
+// WithEventSink installs an EventSink that receives a content_violation
+// event after every Create, Edit, or Remix response that reports one, a
+// quota_exceeded event whenever a QuotaManager rejects a reservation,
+// a lint_warning event for each Lint warning on the params of a Create,
+// Edit, or Remix call, and a circuit_open event whenever the circuit
+// breaker fails a call --
+// so a SIEM or observability pipeline can ingest SDK security and
+// compliance signals without polling Result or wrapping every call.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithEventSink(myOTelSink))
+func WithEventSink(sink EventSink) Option {
+	return func(c *Config) {
+		c.events = sink
+	}
+}
+
+// WithSingleflight coalesces concurrent Create, Edit, or Remix calls
+// carrying identical params (same prompt/instruction, seed, version,
+// and aspect ratio) into a single in-flight API call, sharing its
+// Result with every caller that asked for it. Saves credits in fan-in
+// web workloads where several goroutines race to generate the same
+// thing. Has no effect on calls with differing params.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithSingleflight(true))
+func WithSingleflight(enabled bool) Option {
+	return func(c *Config) {
+		c.singleflight = enabled
+	}
+}
+
+// WithQuotaManager installs a QuotaManager that partitions the
+// client's credit budget across named shares, so a low-priority
+// background job calling image.WithPartition("background-jobs") can't
+// starve the "interactive" share user-facing requests draw from. See
+// package quota for a bundled fixed-budget implementation.
+//
+// Example:
+//
+//	manager := quota.NewManager(10000, []quota.Partition{
+//		{Name: "interactive", SharePercent: 80},
+//		{Name: "background-jobs", SharePercent: 20},
+//	})
+//	client := reve.NewClient(apiKey, reve.WithQuotaManager(manager))
+func WithQuotaManager(manager QuotaManager) Option {
+	return func(c *Config) {
+		c.quota = manager
+	}
+}
+
+// WithPromptScrubber installs a PromptScrubber run against every prompt
+// and edit instruction before it's screened, used as a cache key, or
+// included in diagnostics, so compliance teams can enforce redaction of
+// names, emails, and similar PII embedded in user prompts.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithPromptScrubber(func(prompt string) string {
+//		return emailPattern.ReplaceAllString(prompt, "[redacted]")
+//	}))
+func WithPromptScrubber(scrubber PromptScrubber) Option {
+	return func(c *Config) {
+		c.promptScrubber = scrubber
+	}
+}
+
+// WithBinaryUploads sends reference images to Edit and Remix as
+// multipart/form-data file parts, streamed directly from their source
+// (see types.NewImageFromReader), instead of base64-encoding them into
+// the JSON body. Cuts upload size by roughly a third and avoids holding
+// a base64 copy of the image in memory.
+//
+// The documented Reve endpoints accept JSON with a base64
+// reference_image field; this targets a multipart-capable deployment or
+// future API version, and is off by default so existing integrations
+// are unaffected. Requests without a reference image are unaffected
+// either way.
+func WithBinaryUploads() Option {
+	return func(c *Config) {
+		c.binaryUploads = true
+	}
+}
+
+// WithCompression gzip-compresses JSON request bodies (sent with
+// Content-Encoding: gzip), cutting upload time for large base64
+// reference image sets over slow links. Response bodies need no
+// opt-in: Go's http.Transport already negotiates and decompresses
+// gzip responses automatically, as long as no option here disables it.
+//
+// Multipart bodies sent under WithBinaryUploads are left uncompressed;
+// image formats are already compressed, so gzipping them again just
+// burns CPU.
+func WithCompression() Option {
+	return func(c *Config) {
+		c.compression = true
+	}
+}
+
+// WithErrorBodyCapture attaches a redacted copy of the request body to
+// transport.APIError.RequestBody whenever a Create, Edit, or Remix call
+// fails with a 4xx response, so "why was this rejected" is answerable
+// from a logged error alone. Reference image payload fields are
+// replaced with their size, never their content.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithErrorBodyCapture())
+//	_, err := client.Images.Create(ctx, params)
+//	var apiErr *transport.APIError
+//	if errors.As(err, &apiErr) {
+//		log.Printf("rejected request: %+v", apiErr.RequestBody)
+//	}
+func WithErrorBodyCapture() Option {
+	return func(c *Config) {
+		c.errorBodyCapture = true
+	}
+}
+
+// WithMinDeadlineMargin makes Create, Edit, and Remix fail fast with a
+// *transport.DeadlineError instead of attempting a request when the call's
+// context has less than margin left on its deadline. Without it, a call
+// made on an almost-expired context still starts the request and is then
+// cancelled mid-flight, burning a retry budget slot and quota for nothing.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithMinDeadlineMargin(2*time.Second))
+func WithMinDeadlineMargin(margin time.Duration) Option {
+	return func(c *Config) {
+		c.minDeadlineMargin = margin
+	}
+}
+
+// WithMaxConcurrentRequests caps how many requests this client runs at
+// once. A call made while the cap is already saturated fails immediately
+// with a *transport.RateLimitShedError instead of queuing behind the
+// in-flight ones, so callers under sudden load get a fast, distinguishable
+// error instead of a pile of requests timing out together.
+func WithMaxConcurrentRequests(max int) Option {
+	return func(c *Config) {
+		c.maxConcurrentRequests = max
+	}
+}
+
+// WithAutoDownscale automatically downscales an oversized PNG or JPEG
+// reference image passed to Edit or Remix to maxMegapixels before
+// validating it, instead of Validate failing the call with
+// ErrImageResolutionTooLarge (internal/validator). Pass 0 to use
+// validator.MaxInputMegapixels, the same default Validate enforces.
+//
+// WebP references are unaffected: the standard library has no WebP
+// encoder to write a downscaled result back out, so an oversized WebP
+// reference still fails Validate.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithAutoDownscale(16))
+func WithAutoDownscale(maxMegapixels float64) Option {
+	return func(c *Config) {
+		c.autoDownscale = true
+		c.autoDownscaleMegapixels = maxMegapixels
+	}
+}
+
+// DefaultRegionEndpoints maps a region code to the Reve API base URL
+// WithRegion routes it to. Override a deployment's endpoints with
+// WithRegionEndpoints, e.g. for an enterprise contract fronted by
+// dedicated regional hosts.
+var DefaultRegionEndpoints = map[string]string{
+	"us": DefaultBaseURL,
+	"eu": "https://eu.api.reve.com",
+}
+
+// WithRegion routes every request to the base URL DefaultRegionEndpoints
+// (or a map installed by WithRegionEndpoints) maps region to, and
+// annotates every Result and RawResult with Region, so customers whose
+// compliance requirements demand EU-only processing can pin a client to
+// that region and audit where a given generation actually ran. An
+// unrecognized region leaves BaseURL unchanged but is still recorded on
+// results.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithRegion("eu"))
+func WithRegion(region string) Option {
+	return func(c *Config) {
+		c.region = region
+	}
+}
+
+// WithRegionEndpoints overrides DefaultRegionEndpoints for WithRegion,
+// for self-hosted or enterprise deployments with their own regional
+// hosts. Call before WithRegion in the option list.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey,
+//		reve.WithRegionEndpoints(map[string]string{"eu": "https://eu.internal.example.com"}),
+//		reve.WithRegion("eu"),
+//	)
+func WithRegionEndpoints(endpoints map[string]string) Option {
+	return func(c *Config) {
+		c.regionEndpoints = endpoints
+	}
+}
+
 // WithTransport sets a custom HTTP transport.
 //
 // Example:
@@ -104,6 +490,101 @@ func WithTransport(t http.RoundTripper) Option {
 	}
 }
 
+// WithConnectionPool tunes the HTTP transport's per-host connection
+// pool, worth raising for a client issuing many concurrent requests
+// (e.g. BatchCreate with high Concurrency) against a single API host.
+// Overrides any transport set by an earlier option.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithConnectionPool(transport.PoolConfig{
+//		MaxIdleConns:        100,
+//		MaxIdleConnsPerHost: 50,
+//		IdleConnTimeout:     90 * time.Second,
+//	}))
+func WithConnectionPool(cfg transport.PoolConfig) Option {
+	return func(c *Config) {
+		c.Transport = transport.CreatePooledTransport(cfg)
+	}
+}
+
+// WithRootCAs trusts roots instead of the system root pool, for
+// operating behind a TLS-intercepting corporate proxy whose CA isn't in
+// the system store. Overrides any transport set by an earlier option.
+//
+// Example:
+//
+//	pool := x509.NewCertPool()
+//	pool.AppendCertsFromPEM(corpCA)
+//	client := reve.NewClient(apiKey, reve.WithRootCAs(pool))
+func WithRootCAs(roots *x509.CertPool) Option {
+	return func(c *Config) {
+		c.Transport = transport.CreateTLSTransport(roots)
+	}
+}
+
+// WithCertificatePinning rejects the connection unless one certificate
+// in the server's chain has a SubjectPublicKeyInfo whose base64-encoded
+// SHA-256 digest ("pin-sha256") is in spkiHashes, on top of normal
+// chain verification. Pin the real Reve endpoint in high-security
+// deployments so a compromised or mis-issued CA alone can't intercept
+// traffic. Overrides any transport set by an earlier option.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithCertificatePinning([]string{
+//		"uM5+sMxzPmjvhZ7Yfq0c6f5hH3JbZ1qj3wC/lC7Lk0A=",
+//	}))
+func WithCertificatePinning(spkiHashes []string) Option {
+	return func(c *Config) {
+		c.Transport = transport.CreateCertPinnedTransport(spkiHashes)
+	}
+}
+
+// WithCircuitBreaker enables a circuit breaker: after threshold consecutive
+// 5xx/connection failures, the client fails fast with transport.ErrCircuitOpen
+// instead of hitting the network, probing again after cooldown.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithCircuitBreaker(5, 30*time.Second))
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Config) {
+		c.CircuitThreshold = threshold
+		c.CircuitCooldown = cooldown
+	}
+}
+
+// WithHedging enables opt-in request hedging: if a request carrying an
+// idempotency key (see image.WithIdempotencyKey) hasn't responded within
+// delay, a duplicate request is issued, up to maxHedges extra attempts.
+// The first response wins. Only idempotent requests are hedged, since
+// duplicate non-idempotent calls would double-apply.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithHedging(200*time.Millisecond, 1))
+func WithHedging(delay time.Duration, maxHedges int) Option {
+	return func(c *Config) {
+		c.HedgeDelay = delay
+		c.HedgeMaxHedges = maxHedges
+	}
+}
+
+// WithRecorder wraps the client's transport so every request/response pair
+// is recorded as a sanitized JSON cassette under dir, for VCR-style
+// reproducible debugging and bug reports. See package recorder for the
+// matching replay transport.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithRecorder("testdata/cassettes"))
+func WithRecorder(dir string) Option {
+	return func(c *Config) {
+		c.Transport = recorder.NewRecordingTransport(dir, c.Transport, false)
+	}
+}
+
 // WithHTTPProxy configures an HTTP/HTTPS proxy.
 //
 // Example:
@@ -149,6 +630,42 @@ func WithSOCKS5Proxy(addr, username, password string) Option {
 	}
 }
 
+// WithProxyFunc configures a PAC-like proxy selection function,
+// consulted for every request, for callers routing through different
+// egress points per region or destination rather than one fixed proxy.
+// Returning a nil URL and nil error means connect directly.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithProxyFunc(func(req *http.Request) (*url.URL, error) {
+//		if region := req.Header.Get("X-Reve-Region"); region == "eu" {
+//			return url.Parse("http://eu-proxy:8080")
+//		}
+//		return nil, nil
+//	}))
+func WithProxyFunc(fn func(*http.Request) (*url.URL, error)) Option {
+	return func(c *Config) {
+		c.Transport = transport.CreateProxyFuncTransport(fn)
+	}
+}
+
+// WithSOCKS5ProxyFallback is like WithSOCKS5Proxy, but falls back to a
+// direct connection when the SOCKS5 dial fails, instead of failing the
+// request. Useful where the SOCKS5 endpoint is a best-effort egress
+// point rather than a hard network boundary.
+//
+// Example:
+//
+//	client := reve.NewClient(apiKey, reve.WithSOCKS5ProxyFallback("proxy:1080", "", ""))
+func WithSOCKS5ProxyFallback(addr, username, password string) Option {
+	return func(c *Config) {
+		t, err := transport.CreateSOCKS5FallbackTransport(addr, username, password)
+		if err == nil {
+			c.Transport = t
+		}
+	}
+}
+
 // WithProxyFromEnvironment uses proxy from environment variables.
 // Reads HTTP_PROXY, HTTPS_PROXY, NO_PROXY.
 //
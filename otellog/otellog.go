@@ -0,0 +1,59 @@
+// Package otellog implements image.EventSink by writing events as JSON
+// lines shaped like the OpenTelemetry Log Data Model (Timestamp,
+// SeverityText, Body, Attributes), one per line, to an io.Writer.
+//
+// This does not depend on the OpenTelemetry SDK or speak the OTLP wire
+// protocol -- it produces plain JSON lines that a log-based OTel
+// collector (e.g. one configured with a filelog or stdout receiver) can
+// parse into log records, without adding an SDK dependency to this
+// module. Install it with reve.WithEventSink.
+package otellog
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/shamspias/reve-go/image"
+)
+
+// record is the JSON shape written for each event, field-named after
+// the OTel Log Data Model.
+type record struct {
+	Timestamp    string            `json:"Timestamp"`
+	SeverityText string            `json:"SeverityText"`
+	Body         string            `json:"Body"`
+	Attributes   map[string]string `json:"Attributes,omitempty"`
+}
+
+// Sink writes events to W as newline-delimited JSON log records. It
+// implements image.EventSink. The zero value is not usable; construct
+// one with NewSink.
+type Sink struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// NewSink creates a Sink that writes log records to w.
+func NewSink(w io.Writer) *Sink {
+	return &Sink{w: w}
+}
+
+// Emit implements image.EventSink.
+func (s *Sink) Emit(event image.Event) {
+	rec := record{
+		Timestamp:    event.Time.UTC().Format("2006-01-02T15:04:05.000000000Z"),
+		SeverityText: event.Severity,
+		Body:         event.Name,
+		Attributes:   event.Attributes,
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.w.Write(data)
+}
@@ -0,0 +1,187 @@
+// Package pipeline chains Create, Edit, and postprocessing calls into a
+// single declarative sequence, threading each step's output image into
+// the next and aggregating credit costs, instead of hand-plumbing base64
+// data between calls.
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shamspias/reve-go/image"
+	"github.com/shamspias/reve-go/types"
+)
+
+// Step is one operation in a Pipeline.
+type Step interface {
+	name() string
+	run(ctx context.Context, svc *image.Service, input string) (*types.Result, error)
+}
+
+// Create starts a pipeline with a text-to-image generation. Only valid
+// as the first step, since it takes no input image.
+func Create(params *image.CreateParams) Step {
+	return &createStep{params: params}
+}
+
+type createStep struct {
+	params *image.CreateParams
+}
+
+func (s *createStep) name() string { return "create" }
+
+func (s *createStep) run(ctx context.Context, svc *image.Service, _ string) (*types.Result, error) {
+	return svc.Create(ctx, s.params)
+}
+
+// Edit applies instruction to the preceding step's output image.
+func Edit(instruction string) Step {
+	return &editStep{instruction: instruction}
+}
+
+type editStep struct {
+	instruction string
+}
+
+func (s *editStep) name() string { return "edit" }
+
+func (s *editStep) run(ctx context.Context, svc *image.Service, input string) (*types.Result, error) {
+	if input == "" {
+		return nil, fmt.Errorf("pipeline: edit step requires a preceding image")
+	}
+	return svc.Edit(ctx, &image.EditParams{
+		Instruction:    s.instruction,
+		ReferenceImage: input,
+	})
+}
+
+// Upscale enlarges the preceding step's output image by factor (2, 3, or 4).
+func Upscale(factor int) Step {
+	return &upscaleStep{factor: factor}
+}
+
+type upscaleStep struct {
+	factor int
+}
+
+func (s *upscaleStep) name() string { return "upscale" }
+
+func (s *upscaleStep) run(ctx context.Context, svc *image.Service, input string) (*types.Result, error) {
+	if input == "" {
+		return nil, fmt.Errorf("pipeline: upscale step requires a preceding image")
+	}
+	return svc.Edit(ctx, &image.EditParams{
+		Instruction:    "upscale, enhance detail, preserve composition exactly",
+		ReferenceImage: input,
+		Postprocess:    []types.Postprocess{types.Upscale(s.factor)},
+	})
+}
+
+// RemoveBackground removes the background from the preceding step's
+// output image.
+func RemoveBackground() Step {
+	return &removeBackgroundStep{}
+}
+
+type removeBackgroundStep struct{}
+
+func (s *removeBackgroundStep) name() string { return "remove_background" }
+
+func (s *removeBackgroundStep) run(ctx context.Context, svc *image.Service, input string) (*types.Result, error) {
+	if input == "" {
+		return nil, fmt.Errorf("pipeline: remove_background step requires a preceding image")
+	}
+	return svc.Edit(ctx, &image.EditParams{
+		Instruction:    "remove the background, keep the subject exactly as-is",
+		ReferenceImage: input,
+		Postprocess:    []types.Postprocess{types.RemoveBackground()},
+	})
+}
+
+// StepResult pairs a step's name with the Result it produced.
+type StepResult struct {
+	Step   string
+	Result *types.Result
+}
+
+// Pipeline is a declared sequence of steps to run against a Service.
+type Pipeline struct {
+	svc   *image.Service
+	steps []Step
+
+	// seed, if set (via Branch), is prepended to the trail as the
+	// starting image instead of requiring steps[0] to be Create.
+	seed *types.Result
+}
+
+// New declares a pipeline of steps to run against svc. The first step
+// must be Create; later steps consume the previous step's output image.
+//
+// Example:
+//
+//	p := pipeline.New(client.Images,
+//		pipeline.Create(&image.CreateParams{Prompt: "a ceramic mug, studio lighting"}),
+//		pipeline.Edit("add a sprig of rosemary next to the mug"),
+//		pipeline.Upscale(2),
+//	)
+//	trail, err := p.Execute(ctx)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	final := trail[len(trail)-1].Result
+//	err = final.SaveTo("mug.png")
+func New(svc *image.Service, steps ...Step) *Pipeline {
+	return &Pipeline{svc: svc, steps: steps}
+}
+
+// Branch starts a Pipeline from an already-produced result instead of a
+// Create step, so you can try a different continuation without redoing
+// (and re-paying for) the steps that produced result. steps should be
+// Edit/Upscale/RemoveBackground only -- Create would ignore result.
+//
+// The Reve API has no way to reference a previous generation by ID --
+// Edit always sends the full reference image bytes -- so the first of
+// steps still re-uploads result.Image in full; this only saves redoing
+// the earlier API calls themselves.
+//
+// Example:
+//
+//	trail, _ := pipeline.New(client.Images, pipeline.Create(params), pipeline.Edit("warmer lighting")).Execute(ctx)
+//	warm := trail[len(trail)-1].Result
+//
+//	cool, err := pipeline.Branch(client.Images, warm, pipeline.Edit("cooler lighting instead")).Execute(ctx)
+func Branch(svc *image.Service, result *types.Result, steps ...Step) *Pipeline {
+	return &Pipeline{svc: svc, steps: steps, seed: result}
+}
+
+// Execute runs every step in order, passing each step's output image
+// into the next, and returns the full per-step trail. If a step fails,
+// Execute returns the trail so far alongside the error.
+func (p *Pipeline) Execute(ctx context.Context) ([]StepResult, error) {
+	trail := make([]StepResult, 0, len(p.steps)+1)
+
+	var input string
+	if p.seed != nil {
+		trail = append(trail, StepResult{Step: "seed", Result: p.seed})
+		input = p.seed.Image
+	}
+	for _, step := range p.steps {
+		result, err := step.run(ctx, p.svc, input)
+		if err != nil {
+			return trail, fmt.Errorf("pipeline: step %q: %w", step.name(), err)
+		}
+		trail = append(trail, StepResult{Step: step.name(), Result: result})
+		input = result.Image
+	}
+
+	return trail, nil
+}
+
+// TotalCredits sums CreditsUsed across every step in trail.
+func TotalCredits(trail []StepResult) int {
+	total := 0
+	for _, r := range trail {
+		total += r.Result.CreditsUsed
+	}
+	return total
+}
@@ -0,0 +1,69 @@
+package placeholder
+
+import (
+	"image"
+	"image/color"
+)
+
+// A minimal built-in 3x5 bitmap font, just enough to stamp ASCII banner
+// text onto a placeholder image without pulling in an external font
+// dependency. Unsupported characters render as a blank glyph.
+const (
+	glyphWidth  = 3
+	glyphHeight = 5
+	fontHeight  = glyphHeight
+)
+
+// glyphs maps a character to 5 rows of a 3-bit mask (bit 2 = leftmost
+// column).
+var glyphs = map[rune][glyphHeight]uint8{
+	'A': {0b010, 0b101, 0b111, 0b101, 0b101},
+	'B': {0b110, 0b101, 0b110, 0b101, 0b110},
+	'C': {0b011, 0b100, 0b100, 0b100, 0b011},
+	'D': {0b110, 0b101, 0b101, 0b101, 0b110},
+	'E': {0b111, 0b100, 0b110, 0b100, 0b111},
+	'I': {0b111, 0b010, 0b010, 0b010, 0b111},
+	'L': {0b100, 0b100, 0b100, 0b100, 0b111},
+	'M': {0b101, 0b111, 0b111, 0b101, 0b101},
+	'N': {0b101, 0b111, 0b111, 0b111, 0b101},
+	'S': {0b011, 0b100, 0b010, 0b001, 0b110},
+	'T': {0b111, 0b010, 0b010, 0b010, 0b010},
+	'U': {0b101, 0b101, 0b101, 0b101, 0b111},
+	'0': {0b111, 0b101, 0b101, 0b101, 0b111},
+	'1': {0b010, 0b110, 0b010, 0b010, 0b111},
+	'2': {0b110, 0b001, 0b010, 0b100, 0b111},
+	'3': {0b110, 0b001, 0b010, 0b001, 0b110},
+	'4': {0b101, 0b101, 0b111, 0b001, 0b001},
+	'5': {0b111, 0b100, 0b110, 0b001, 0b110},
+	'6': {0b011, 0b100, 0b110, 0b101, 0b010},
+	'7': {0b111, 0b001, 0b010, 0b010, 0b010},
+	'8': {0b111, 0b101, 0b010, 0b101, 0b111},
+	'9': {0b111, 0b101, 0b111, 0b001, 0b001},
+	'x': {0b000, 0b101, 0b010, 0b101, 0b000},
+	' ': {0b000, 0b000, 0b000, 0b000, 0b000},
+}
+
+// drawText stamps s onto img at (x, y) using the built-in glyph set,
+// uppercasing letters since the font only covers A-Z. Unknown
+// characters (and lowercase letters other than "x") render as a blank
+// glyph rather than failing.
+func drawText(img *image.RGBA, x, y int, s string, c color.Color) {
+	cursor := x
+	for _, r := range s {
+		if r >= 'a' && r <= 'z' && r != 'x' {
+			r -= 'a' - 'A'
+		}
+		drawGlyph(img, cursor, y, glyphs[r], c)
+		cursor += glyphWidth + 1
+	}
+}
+
+func drawGlyph(img *image.RGBA, x, y int, rows [glyphHeight]uint8, c color.Color) {
+	for row, bits := range rows {
+		for col := 0; col < glyphWidth; col++ {
+			if bits&(1<<(glyphWidth-1-col)) != 0 {
+				img.Set(x+col, y+row, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Package placeholder renders labeled placeholder images: a solid
+// background stamped with the requested prompt text, pixel dimensions,
+// and a "SIMULATED" banner, sized to match a requested aspect ratio and
+// encoded in a requested output format. It backs package simulate's mock
+// transport and is exported so callers can also use it directly as a
+// graceful-degradation fallback when the real API is unreachable.
+package placeholder
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/shamspias/reve-go/types"
+)
+
+// baseDimension is the longest-side pixel size a placeholder is rendered
+// at; the other side is scaled to match the requested aspect ratio.
+const baseDimension = 1024
+
+// Options configures Generate.
+type Options struct {
+	// AspectRatio determines the rendered pixel dimensions. Default:
+	// types.Ratio1x1.
+	AspectRatio types.AspectRatio
+
+	// Format selects the encoding. Default: types.FormatPNG. WebP has no
+	// encoder in the standard library, so it is encoded as PNG.
+	Format types.OutputFormat
+
+	// Label is stamped on the image, typically the originating prompt.
+	// It is truncated to fit on a single line.
+	Label string
+
+	// Background overrides the placeholder's fill color. Default: a
+	// mid-gray so the banner text is legible.
+	Background color.Color
+}
+
+// Generate renders a labeled placeholder image per opts and returns it
+// encoded in opts.Format.
+func Generate(opts Options) ([]byte, error) {
+	width, height := pixelDimensions(opts.AspectRatio)
+
+	bg := opts.Background
+	if bg == nil {
+		bg = color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	fillBackground(img, bg)
+
+	banner := fmt.Sprintf("SIMULATED %dx%d", width, height)
+	drawText(img, 8, 8, banner, color.White)
+	if opts.Label != "" {
+		drawText(img, 8, 8+fontHeight+4, truncateLabel(opts.Label, width), color.White)
+	}
+
+	var buf bytes.Buffer
+	switch opts.Format {
+	case types.FormatJPEG:
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+			return nil, fmt.Errorf("placeholder: encode jpeg: %w", err)
+		}
+	default:
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, fmt.Errorf("placeholder: encode png: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func pixelDimensions(ratio types.AspectRatio) (int, int) {
+	w, h := ratio.Dimensions()
+	if w == 0 || h == 0 {
+		return baseDimension, baseDimension
+	}
+	if w >= h {
+		return baseDimension, baseDimension * h / w
+	}
+	return baseDimension * w / h, baseDimension
+}
+
+func fillBackground(img *image.RGBA, c color.Color) {
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			img.Set(x, y, c)
+		}
+	}
+}
+
+// truncateLabel keeps the label to roughly what fits on one line at the
+// rendered image's width, since Generate has no text-wrapping.
+func truncateLabel(label string, width int) string {
+	maxChars := width / (glyphWidth + 1)
+	if maxChars <= 0 || len(label) <= maxChars {
+		return label
+	}
+	if maxChars <= 3 {
+		return label[:maxChars]
+	}
+	return label[:maxChars-3] + "..."
+}
@@ -0,0 +1,203 @@
+// Package prompt provides a fluent builder for assembling and
+// length-validating Reve prompts, so Create/Edit/Remix callers don't have
+// to hand-concatenate strings (and, for Remix, <img> reference tags).
+//
+// Example:
+//
+//	p, err := prompt.New().
+//		Subject("a red fox").
+//		Style("studio ghibli watercolor").
+//		Lighting("golden hour").
+//		Camera("35mm, shallow depth of field").
+//		Negative("no text, no watermark").
+//		Build()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	result, err := client.Images.Create(ctx, &image.CreateParams{Prompt: p})
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shamspias/reve-go/internal/validator"
+)
+
+// TruncateStrategy controls what Build does when the assembled prompt
+// exceeds the length limit.
+type TruncateStrategy int
+
+const (
+	// TruncateNone returns validator.ErrPromptTooLong instead of
+	// truncating. The default.
+	TruncateNone TruncateStrategy = iota
+
+	// TruncateEnd cuts the assembled prompt down to the limit from the
+	// end, keeping Subject and earlier clauses intact where possible.
+	TruncateEnd
+
+	// TruncateDropLeast drops whole clauses, least-essential first
+	// (Negative, then Camera, then Lighting, then Style), until the
+	// prompt fits.
+	TruncateDropLeast
+)
+
+// Builder assembles a prompt from named clauses. The zero value is not
+// ready to use; create one with New.
+type Builder struct {
+	refs     []int
+	subject  string
+	style    string
+	lighting string
+	camera   string
+	negative string
+	seed     string
+
+	maxLength int
+	strategy  TruncateStrategy
+}
+
+// New creates a Builder with the default 2560-character limit and no
+// truncation (Build returns an error if the prompt is too long).
+func New() *Builder {
+	return &Builder{maxLength: validator.MaxPromptLength}
+}
+
+// Refs prepends <img>N</img> reference tags for the given 1-based image
+// indices, for use with Remix.
+func (b *Builder) Refs(indices ...int) *Builder {
+	b.refs = indices
+	return b
+}
+
+// Subject sets the core description of what to generate.
+func (b *Builder) Subject(s string) *Builder {
+	b.subject = s
+	return b
+}
+
+// Style sets an artistic/visual style clause.
+func (b *Builder) Style(s string) *Builder {
+	b.style = s
+	return b
+}
+
+// Lighting sets a lighting clause.
+func (b *Builder) Lighting(s string) *Builder {
+	b.lighting = s
+	return b
+}
+
+// Camera sets a camera/lens/composition clause.
+func (b *Builder) Camera(s string) *Builder {
+	b.camera = s
+	return b
+}
+
+// Negative sets what to avoid, appended as a trailing clause.
+func (b *Builder) Negative(s string) *Builder {
+	b.negative = s
+	return b
+}
+
+// Seed sets a free-text seed hint (e.g. "seed 42" or "variation of the
+// previous render"), appended after Camera and before Negative.
+func (b *Builder) Seed(hint string) *Builder {
+	b.seed = hint
+	return b
+}
+
+// WithMaxLength overrides the 2560-character default limit.
+func (b *Builder) WithMaxLength(n int) *Builder {
+	b.maxLength = n
+	return b
+}
+
+// WithTruncateStrategy sets how Build handles an over-length prompt.
+func (b *Builder) WithTruncateStrategy(s TruncateStrategy) *Builder {
+	b.strategy = s
+	return b
+}
+
+// Build assembles the final prompt string, applying the configured
+// TruncateStrategy if it exceeds the length limit.
+func (b *Builder) Build() (string, error) {
+	clauses := b.clauses()
+	p := b.assemble(clauses)
+
+	if validator.PromptLength(p) <= b.maxLength {
+		return p, nil
+	}
+
+	switch b.strategy {
+	case TruncateEnd:
+		return strings.TrimSpace(validator.TruncatePrompt(p, b.maxLength)), nil
+	case TruncateDropLeast:
+		return b.buildDroppingLeast(clauses)
+	default:
+		return "", validator.ErrPromptTooLong
+	}
+}
+
+// clauses returns the builder's non-empty clauses, most to least
+// essential, not yet including Refs or Subject.
+func (b *Builder) clauses() []string {
+	var clauses []string
+	for _, c := range []string{b.style, b.lighting, b.camera, b.seed, b.negative} {
+		if c != "" {
+			clauses = append(clauses, c)
+		}
+	}
+	return clauses
+}
+
+func (b *Builder) assemble(clauses []string) string {
+	var parts []string
+	for _, n := range b.refs {
+		parts = append(parts, fmt.Sprintf("<img>%d</img>", n))
+	}
+	if b.subject != "" {
+		parts = append(parts, b.subject)
+	}
+	parts = append(parts, clauses...)
+	return strings.Join(parts, ", ")
+}
+
+// buildDroppingLeast drops Negative, then Camera, then Lighting, then
+// Style (in that order) until the prompt fits, or gives up and returns
+// ErrPromptTooLong if Subject and Refs alone still don't fit.
+func (b *Builder) buildDroppingLeast(clauses []string) (string, error) {
+	dropOrder := []string{b.negative, b.camera, b.lighting, b.style}
+
+	remaining := append([]string{}, clauses...)
+	for _, drop := range dropOrder {
+		if drop == "" {
+			continue
+		}
+		remaining = removeOne(remaining, drop)
+		p := b.assemble(remaining)
+		if validator.PromptLength(p) <= b.maxLength {
+			return p, nil
+		}
+	}
+
+	p := b.assemble(remaining)
+	if validator.PromptLength(p) <= b.maxLength {
+		return p, nil
+	}
+	return "", validator.ErrPromptTooLong
+}
+
+func removeOne(items []string, target string) []string {
+	out := make([]string, 0, len(items))
+	removed := false
+	for _, it := range items {
+		if !removed && it == target {
+			removed = true
+			continue
+		}
+		out = append(out, it)
+	}
+	return out
+}
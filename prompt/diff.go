@@ -0,0 +1,94 @@
+package prompt
+
+import "strings"
+
+// DiffOpType identifies a DiffOp's kind.
+type DiffOpType int
+
+// Kinds of word-level diff operation.
+const (
+	DiffEqual DiffOpType = iota
+	DiffInsert
+	DiffDelete
+)
+
+// DiffOp is one word-level operation in a Diff result.
+type DiffOp struct {
+	Type DiffOpType
+	Word string
+}
+
+// Diff returns a word-level diff from original to updated, computed via
+// the longest common subsequence of whitespace-split words. Useful for
+// showing which wording change, across prompt iterations, produced
+// which change in the generated image.
+func Diff(original, updated string) []DiffOp {
+	a := strings.Fields(original)
+	b := strings.Fields(updated)
+	pairs := lcsIndices(a, b)
+
+	var ops []DiffOp
+	ai, bi := 0, 0
+	for _, p := range pairs {
+		for ai < p.i {
+			ops = append(ops, DiffOp{Type: DiffDelete, Word: a[ai]})
+			ai++
+		}
+		for bi < p.j {
+			ops = append(ops, DiffOp{Type: DiffInsert, Word: b[bi]})
+			bi++
+		}
+		ops = append(ops, DiffOp{Type: DiffEqual, Word: a[ai]})
+		ai++
+		bi++
+	}
+	for ; ai < len(a); ai++ {
+		ops = append(ops, DiffOp{Type: DiffDelete, Word: a[ai]})
+	}
+	for ; bi < len(b); bi++ {
+		ops = append(ops, DiffOp{Type: DiffInsert, Word: b[bi]})
+	}
+	return ops
+}
+
+// lcsPair is one matched (a-index, b-index) position in a longest
+// common subsequence.
+type lcsPair struct{ i, j int }
+
+// lcsIndices returns the index pairs of a's and b's longest common
+// subsequence, in order, via the standard O(len(a)*len(b)) DP.
+func lcsIndices(a, b []string) []lcsPair {
+	n, m := len(a), len(b)
+	dp := make([][]int, n+1)
+	for i := range dp {
+		dp[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				dp[i][j] = dp[i+1][j+1] + 1
+			case dp[i+1][j] >= dp[i][j+1]:
+				dp[i][j] = dp[i+1][j]
+			default:
+				dp[i][j] = dp[i][j+1]
+			}
+		}
+	}
+
+	var pairs []lcsPair
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			pairs = append(pairs, lcsPair{i, j})
+			i++
+			j++
+		case dp[i+1][j] >= dp[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,52 @@
+package prompt
+
+import (
+	"sync"
+
+	"github.com/shamspias/reve-go/manifest"
+)
+
+// HistoryEntry records one step in a prompt's iterative refinement: the
+// prompt text used, its word-level Diff against the previous entry's
+// prompt (nil for the first entry), and the Manifest produced by that
+// prompt, if any.
+type HistoryEntry struct {
+	Prompt   string
+	Diff     []DiffOp
+	Manifest *manifest.Manifest
+}
+
+// History tracks how a prompt evolved across a hero-image refinement
+// session, pairing each wording change with the manifest it produced,
+// so teams can see which wording change produced which visual change.
+// The zero value is not ready to use; create one with NewHistory.
+type History struct {
+	mu      sync.Mutex
+	entries []HistoryEntry
+}
+
+// NewHistory creates an empty History.
+func NewHistory() *History {
+	return &History{}
+}
+
+// Record appends an entry for text and the manifest it produced (nil if
+// the call that used text failed before producing one), diffing text
+// against the previous entry's prompt.
+func (h *History) Record(text string, m *manifest.Manifest) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entry := HistoryEntry{Prompt: text, Manifest: m}
+	if n := len(h.entries); n > 0 {
+		entry.Diff = Diff(h.entries[n-1].Prompt, text)
+	}
+	h.entries = append(h.entries, entry)
+}
+
+// Entries returns a copy of the recorded history, oldest first.
+func (h *History) Entries() []HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]HistoryEntry(nil), h.entries...)
+}
@@ -0,0 +1,61 @@
+package prompt
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/shamspias/reve-go/internal/validator"
+)
+
+// Template renders a text/template-based prompt with variables, so a
+// library of templates (e.g. one per product line) can be parsed once
+// and reused across a batch of CreateParams with different data. The
+// zero value is not ready to use; create one with ParseTemplate.
+type Template struct {
+	name string
+	tmpl *template.Template
+}
+
+// ParseTemplate parses text as a text/template prompt, catching syntax
+// errors (e.g. an unclosed "{{.Product}}") up front instead of at
+// render time deep inside a batch run.
+//
+// Example:
+//
+//	tpl, err := prompt.ParseTemplate("product-shot",
+//		"{{.Product}} on a {{.Background}} background, studio lighting")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	text, err := tpl.Render(map[string]string{
+//		"Product":    "a ceramic mug",
+//		"Background": "white",
+//	})
+func ParseTemplate(name, text string) (*Template, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{name: name, tmpl: t}, nil
+}
+
+// Name returns the template's name, as given to ParseTemplate.
+func (t *Template) Name() string {
+	return t.name
+}
+
+// Render executes the template against data and validates the result as
+// a prompt (non-empty, within the length limit), so a bad substitution
+// fails at render time rather than after a wasted API call.
+func (t *Template) Render(data any) (string, error) {
+	var buf strings.Builder
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	rendered := buf.String()
+	if err := validator.ValidatePrompt(rendered); err != nil {
+		return "", err
+	}
+	return rendered, nil
+}
@@ -0,0 +1,131 @@
+// Package provenance attaches and verifies content-credential claims
+// on generated images, for publishing pipelines that require evidence
+// an image came from an authorized AI generator.
+//
+// It is not a full C2PA (Coalition for Content Provenance and
+// Authenticity) implementation: it doesn't produce C2PA's CBOR/JUMBF
+// manifest box or chain of trust via X.509 certificates, since that
+// needs a COSE/CBOR stack this module doesn't otherwise carry. Instead
+// it embeds a minimal JSON claim — generator, assertions, HMAC
+// signature — under the same tEXt/COM embedding package.metadata uses,
+// structured so the fields map onto their C2PA counterparts
+// (c2pa.actions-style assertions) if a caller later upgrades to a full
+// C2PA signer.
+package provenance
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/shamspias/reve-go/metadata"
+)
+
+// claimKeyword is the tEXt/COM keyword a Claim is embedded under,
+// distinguishing it from a package metadata.Metadata payload carried
+// in the same image.
+const claimKeyword = "reve:c2pa"
+
+// Claim is a minimal content-credential record for one generated image.
+type Claim struct {
+	// Generator identifies the software that produced the image, e.g.
+	// "reve-go/1.0".
+	Generator string `json:"generator"`
+
+	// Assertions are claims about how the image was produced, analogous
+	// to C2PA assertions (e.g. label "c2pa.actions" with a "c2pa_action"
+	// of "c2pa.created").
+	Assertions []Assertion `json:"assertions"`
+
+	// CreatedAt is when the claim was made.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Signature is the hex-encoded HMAC-SHA256 over the fields above,
+	// set by Sign. Empty until signed.
+	Signature string `json:"signature,omitempty"`
+}
+
+// Assertion is a single labeled claim within a Claim.
+type Assertion struct {
+	Label string         `json:"label"`
+	Data  map[string]any `json:"data,omitempty"`
+}
+
+// NewClaim builds an unsigned Claim recording that generator produced
+// an image at createdAt.
+func NewClaim(generator string, createdAt time.Time) *Claim {
+	return &Claim{
+		Generator: generator,
+		CreatedAt: createdAt,
+		Assertions: []Assertion{
+			{Label: "c2pa.actions", Data: map[string]any{"action": "c2pa.created"}},
+		},
+	}
+}
+
+// ErrUnsigned is returned by Verify when the claim has no Signature.
+var ErrUnsigned = errors.New("provenance: not signed")
+
+// ErrInvalidSignature is returned by Verify when the claim's Signature
+// doesn't match key.
+var ErrInvalidSignature = errors.New("provenance: signature invalid")
+
+// Sign computes c's Signature over its claim fields using key.
+func (c *Claim) Sign(key []byte) error {
+	mac, err := c.mac(key)
+	if err != nil {
+		return err
+	}
+	c.Signature = hex.EncodeToString(mac)
+	return nil
+}
+
+// Verify reports whether c's Signature matches key.
+func (c *Claim) Verify(key []byte) error {
+	if c.Signature == "" {
+		return ErrUnsigned
+	}
+
+	given, err := hex.DecodeString(c.Signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac, err := c.mac(key)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(given, mac) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// mac computes the HMAC-SHA256 over c's claim fields, excluding
+// Signature itself.
+func (c *Claim) mac(key []byte) ([]byte, error) {
+	unsigned := *c
+	unsigned.Signature = ""
+	canonical, err := json.Marshal(unsigned)
+	if err != nil {
+		return nil, err
+	}
+
+	h := hmac.New(sha256.New, key)
+	h.Write(canonical)
+	return h.Sum(nil), nil
+}
+
+// Attach embeds claim into data (a PNG or JPEG image), returning the
+// updated bytes. Sign claim before attaching it if the claim should be
+// verifiable later.
+func Attach(data []byte, claim Claim) ([]byte, error) {
+	payload, err := json.Marshal(claim)
+	if err != nil {
+		return nil, err
+	}
+	return metadata.EmbedRaw(data, claimKeyword, payload)
+}
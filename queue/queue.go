@@ -0,0 +1,84 @@
+//go:build experimental
+
+// Package queue is experimental: see the root reve package doc's
+// Stability section. Its exported API can change or disappear in a
+// minor or patch release without that counting as a breaking change.
+// Build with -tags experimental to use it.
+//
+// Package queue runs Create calls across a fixed worker pool instead of
+// one goroutine per in-flight request, handing back a Job to wait on.
+package queue
+
+import (
+	"context"
+	"sync"
+
+	"github.com/shamspias/reve-go/image"
+	"github.com/shamspias/reve-go/types"
+)
+
+// Job is a single queued Create call and its eventual result.
+type Job struct {
+	done   chan struct{}
+	result *types.Result
+	err    error
+}
+
+// Wait blocks until the job completes, or ctx is done first.
+func (j *Job) Wait(ctx context.Context) (*types.Result, error) {
+	select {
+	case <-j.done:
+		return j.result, j.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Queue distributes queued Create calls across a fixed number of
+// worker goroutines.
+type Queue struct {
+	images *image.Service
+	jobs   chan func()
+	wg     sync.WaitGroup
+}
+
+// New starts a Queue backed by workers goroutines, each pulling from a
+// shared, unbuffered backlog.
+func New(images *image.Service, workers int) *Queue {
+	q := &Queue{images: images, jobs: make(chan func())}
+	for i := 0; i < workers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+	return q
+}
+
+func (q *Queue) work() {
+	defer q.wg.Done()
+	for job := range q.jobs {
+		job()
+	}
+}
+
+// Enqueue schedules params to run through Create on the next free
+// worker and returns a Job to wait on. Enqueue blocks until a worker
+// accepts the job or ctx is done.
+func (q *Queue) Enqueue(ctx context.Context, params *image.CreateParams, opts ...image.RequestOption) (*Job, error) {
+	j := &Job{done: make(chan struct{})}
+	select {
+	case q.jobs <- func() {
+		defer close(j.done)
+		j.result, j.err = q.images.Create(ctx, params, opts...)
+	}:
+		return j, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops accepting new jobs and waits for in-flight jobs to
+// finish. Enqueue must not be called after Close.
+func (q *Queue) Close() {
+	close(q.jobs)
+	q.wg.Wait()
+}
@@ -0,0 +1,99 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestManagerReserveWithinLimit(t *testing.T) {
+	m := NewManager(1000, []Partition{{Name: "interactive", SharePercent: 50}})
+
+	if err := m.Reserve(context.Background(), "interactive", 400); err != nil {
+		t.Fatalf("Reserve() error = %v, want nil", err)
+	}
+}
+
+func TestManagerReserveExceedsLimit(t *testing.T) {
+	m := NewManager(1000, []Partition{{Name: "interactive", SharePercent: 50}})
+	m.Record("interactive", 500)
+
+	err := m.Reserve(context.Background(), "interactive", 1)
+	var quotaErr *ErrQuotaExceeded
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("Reserve() error = %v, want *ErrQuotaExceeded", err)
+	}
+	if quotaErr.Limit != 500 {
+		t.Errorf("Limit = %d, want 500", quotaErr.Limit)
+	}
+}
+
+func TestManagerUnconfiguredPartitionIsUnrestricted(t *testing.T) {
+	m := NewManager(100, []Partition{{Name: "interactive", SharePercent: 50}})
+
+	if err := m.Reserve(context.Background(), "background", 1_000_000); err != nil {
+		t.Errorf("Reserve() for an unconfigured partition = %v, want nil (unrestricted)", err)
+	}
+}
+
+func TestManagerReserveConcurrentCallsDontExceedLimit(t *testing.T) {
+	m := NewManager(1000, []Partition{{Name: "interactive", SharePercent: 50}})
+
+	const callers = 20
+	const credits = 10
+	var admitted int32
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := m.Reserve(context.Background(), "interactive", credits); err == nil {
+				atomic.AddInt32(&admitted, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, want := int(admitted)*credits, 500; got > want {
+		t.Errorf("admitted %d credits, want at most %d (limit)", got, want)
+	}
+	if m.used["interactive"] != int(admitted)*credits {
+		t.Errorf("used = %d, want %d (admitted reservations should be committed)", m.used["interactive"], int(admitted)*credits)
+	}
+}
+
+func TestManagerReleaseGivesBackReservation(t *testing.T) {
+	m := NewManager(1000, []Partition{{Name: "interactive", SharePercent: 50}})
+
+	if err := m.Reserve(context.Background(), "interactive", 500); err != nil {
+		t.Fatalf("Reserve() error = %v, want nil", err)
+	}
+	m.Release("interactive", 500)
+
+	if err := m.Reserve(context.Background(), "interactive", 500); err != nil {
+		t.Errorf("Reserve() after Release = %v, want nil (reservation should be given back)", err)
+	}
+}
+
+func TestManagerReleaseUnconfiguredPartitionIsNoop(t *testing.T) {
+	m := NewManager(100, []Partition{{Name: "interactive", SharePercent: 50}})
+
+	m.Release("background", 1_000_000)
+}
+
+func TestManagerResetClearsUsage(t *testing.T) {
+	m := NewManager(1000, []Partition{{Name: "interactive", SharePercent: 50}})
+	m.Record("interactive", 500)
+
+	if err := m.Reserve(context.Background(), "interactive", 1); err == nil {
+		t.Fatal("Reserve() error = nil, want ErrQuotaExceeded before Reset")
+	}
+
+	m.Reset()
+
+	if err := m.Reserve(context.Background(), "interactive", 1); err != nil {
+		t.Errorf("Reserve() after Reset = %v, want nil", err)
+	}
+}
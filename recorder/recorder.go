@@ -0,0 +1,162 @@
+// Package recorder provides VCR-style request/response recording and
+// replay for the Reve SDK, enabling reproducible debugging and
+// integration tests without hitting the live API.
+//
+// Record a session against the real API, then replay the same cassettes
+// in tests:
+//
+//	client := reve.NewClient(apiKey, reve.WithRecorder("testdata/cassettes"))
+//	// ... exercise the client; one JSON file per request is written ...
+//
+//	replay, err := recorder.NewReplayTransport("testdata/cassettes")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	client := reve.NewClient("unused", reve.WithTransport(replay))
+package recorder
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+)
+
+// redactedHeaders are never written to a cassette.
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+}
+
+// Cassette is the sanitized, on-disk representation of one request/response
+// pair.
+type Cassette struct {
+	Method         string            `json:"method"`
+	Path           string            `json:"path"`
+	RequestHeaders map[string]string `json:"request_headers"`
+	RequestBody    string            `json:"request_body,omitempty"`
+	RequestHash    string            `json:"request_hash,omitempty"`
+
+	StatusCode      int               `json:"status_code"`
+	ResponseHeaders map[string]string `json:"response_headers"`
+	ResponseBody    string            `json:"response_body,omitempty"`
+	ResponseIsImage bool              `json:"response_is_image,omitempty"`
+}
+
+// RecordingTransport wraps an http.RoundTripper, writing a sanitized
+// cassette for every request/response pair to dir.
+type RecordingTransport struct {
+	dir        string
+	next       http.RoundTripper
+	hashImages bool
+	seq        atomic.Int64
+}
+
+// NewRecordingTransport creates a RecordingTransport writing cassette files
+// under dir, delegating actual requests to next (http.DefaultTransport if
+// nil). When hashImages is true, image response bodies are stored as their
+// SHA-256 hash instead of raw bytes.
+func NewRecordingTransport(dir string, next http.RoundTripper, hashImages bool) *RecordingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &RecordingTransport{dir: dir, next: next, hashImages: hashImages}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		reqBody, _ = io.ReadAll(req.Body)
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if readErr != nil {
+		return nil, readErr
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	cassette := &Cassette{
+		Method:          req.Method,
+		Path:            req.URL.Path,
+		RequestHeaders:  sanitizeHeaders(req.Header),
+		RequestBody:     string(reqBody),
+		RequestHash:     HashRequest(req.Method, req.URL.Path, reqBody),
+		StatusCode:      resp.StatusCode,
+		ResponseHeaders: sanitizeHeaders(resp.Header),
+	}
+
+	if isImageContentType(resp.Header.Get("Content-Type")) {
+		cassette.ResponseIsImage = true
+		if t.hashImages {
+			sum := sha256.Sum256(respBody)
+			cassette.ResponseBody = hex.EncodeToString(sum[:])
+		} else {
+			cassette.ResponseBody = string(respBody)
+		}
+	} else {
+		cassette.ResponseBody = string(respBody)
+	}
+
+	if err := t.write(cassette); err != nil {
+		return resp, nil
+	}
+
+	return resp, nil
+}
+
+func (t *RecordingTransport) write(c *Cassette) error {
+	if err := os.MkdirAll(t.dir, 0755); err != nil {
+		return err
+	}
+	n := t.seq.Add(1)
+	name := fmt.Sprintf("%04d-%s.json", n, c.RequestHash[:min(12, len(c.RequestHash))])
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(t.dir, name), data, 0644)
+}
+
+// HashRequest returns a stable content hash for a request, used both as a
+// cassette filename component and as the replay lookup key.
+func HashRequest(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func sanitizeHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if redactedHeaders[k] || len(v) == 0 {
+			continue
+		}
+		out[k] = v[0]
+	}
+	return out
+}
+
+func isImageContentType(ct string) bool {
+	switch ct {
+	case "image/png", "image/jpeg", "image/webp":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ReplayTransport serves previously recorded cassettes instead of making
+// real HTTP calls, matching requests by method, path, and body hash.
+type ReplayTransport struct {
+	mu           sync.Mutex
+	byHash       map[string]*Cassette
+	NotFoundFunc func(req *http.Request) (*http.Response, error)
+}
+
+// NewReplayTransport loads every cassette under dir into memory, keyed by
+// request hash, ready to serve RoundTrip calls.
+func NewReplayTransport(dir string) (*ReplayTransport, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("recorder: read cassette dir: %w", err)
+	}
+
+	t := &ReplayTransport{byHash: make(map[string]*Cassette)}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("recorder: read %s: %w", e.Name(), err)
+		}
+		var c Cassette
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("recorder: decode %s: %w", e.Name(), err)
+		}
+		t.byHash[c.RequestHash] = &c
+	}
+	return t, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+	hash := HashRequest(req.Method, req.URL.Path, body)
+
+	t.mu.Lock()
+	c, ok := t.byHash[hash]
+	t.mu.Unlock()
+
+	if !ok {
+		if t.NotFoundFunc != nil {
+			return t.NotFoundFunc(req)
+		}
+		return nil, fmt.Errorf("recorder: no cassette recorded for %s %s", req.Method, req.URL.Path)
+	}
+
+	header := http.Header{}
+	for k, v := range c.ResponseHeaders {
+		header.Set(k, v)
+	}
+
+	return &http.Response{
+		StatusCode: c.StatusCode,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader([]byte(c.ResponseBody))),
+		Request:    req,
+	}, nil
+}
@@ -5,6 +5,8 @@ package reve
 
 import (
 	"github.com/shamspias/reve-go/image"
+	"github.com/shamspias/reve-go/internal/transport"
+	"github.com/shamspias/reve-go/internal/validator"
 	"github.com/shamspias/reve-go/types"
 )
 
@@ -31,6 +33,9 @@ type (
 	// RawResult represents a raw binary result.
 	RawResult = types.RawResult
 
+	// Generation is implemented by both Result and RawResult.
+	Generation = types.Generation
+
 	// CreateParams is parameters for image creation.
 	CreateParams = image.CreateParams
 
@@ -48,6 +53,31 @@ type (
 
 	// Cost represents an estimated cost.
 	Cost = image.Cost
+
+	// RequestOption customizes a single Create/Edit/Remix call.
+	RequestOption = image.RequestOption
+
+	// Warning is a non-fatal observation from CreateParams.Lint,
+	// EditParams.Lint, or RemixParams.Lint.
+	Warning = image.Warning
+
+	// RemixBuilder assembles RemixParams from named reference images.
+	// See NewRemixBuilder.
+	RemixBuilder = image.RemixBuilder
+
+	// Phase identifies a stage in a Create call's lifecycle, reported by
+	// Images.CreateWithProgress.
+	Phase = image.Phase
+
+	// ProgressEvent is reported to Images.CreateWithProgress's callback.
+	ProgressEvent = image.ProgressEvent
+)
+
+// Phase values for ProgressEvent.
+const (
+	PhaseQueued     = image.PhaseQueued
+	PhaseRequesting = image.PhaseRequesting
+	PhaseDone       = image.PhaseDone
 )
 
 // Aspect ratio constants.
@@ -81,6 +111,31 @@ const (
 	FormatWebP = types.FormatWebP
 )
 
+// Sentinel errors for errors.Is, classifying a failed Create/Edit/Remix
+// call without importing internal/transport or type-asserting
+// *transport.APIError:
+//
+//	_, err := client.Images.Create(ctx, params)
+//	if errors.Is(err, reve.ErrRateLimited) {
+//		// back off and retry later
+//	}
+var (
+	// ErrRateLimited matches an error from a 429 response or
+	// ErrCodeRateLimit.
+	ErrRateLimited = transport.ErrRateLimited
+
+	// ErrInsufficientCredits matches an error from a 402 response or
+	// ErrCodeInsufficientFunds.
+	ErrInsufficientCredits = transport.ErrInsufficientCredits
+
+	// ErrContentViolation matches an error from ErrCodeContentViolation.
+	ErrContentViolation = transport.ErrContentViolation
+
+	// ErrAuth matches an error from a 401 response or
+	// ErrCodeInvalidAPIKey.
+	ErrAuth = transport.ErrAuth
+)
+
 // Helper functions re-exported for convenience.
 var (
 	// NewImage creates an Image from bytes.
@@ -104,6 +159,15 @@ var (
 	// DetectFormat detects format from file path.
 	DetectFormat = types.DetectFormat
 
+	// PromptLength returns a prompt's length in UTF-8 runes, matching
+	// how the API's MaxPromptLength is defined -- not len(prompt), which
+	// counts bytes and overcounts multi-byte scripts and emoji.
+	PromptLength = validator.PromptLength
+
+	// TruncatePrompt cuts a prompt down to at most n runes without
+	// splitting a multi-byte rune.
+	TruncatePrompt = validator.TruncatePrompt
+
 	// EstimateCreate estimates create cost.
 	EstimateCreate = image.EstimateCreate
 
@@ -119,6 +183,30 @@ var (
 	// DefaultBatchConfig returns default batch config.
 	DefaultBatchConfig = image.DefaultBatchConfig
 
+	// NewRemixBuilder creates a RemixBuilder for assembling RemixParams
+	// from named reference images instead of hand-counted indices.
+	NewRemixBuilder = image.NewRemixBuilder
+
+	// WithRequestTimeout bounds a single request's whole call, retries
+	// included; it cannot extend an attempt past the client's timeout.
+	WithRequestTimeout = image.WithRequestTimeout
+
+	// WithRequestRetry overrides the retry count for a single request.
+	WithRequestRetry = image.WithRequestRetry
+
+	// WithRequestHeader sets an additional header on a single request.
+	WithRequestHeader = image.WithRequestHeader
+
+	// WithIdempotencyKey marks a request as safe to retry or hedge blindly.
+	WithIdempotencyKey = image.WithIdempotencyKey
+
+	// WithIfNoneMatch sets If-None-Match for cheap cache revalidation on
+	// a single raw request.
+	WithIfNoneMatch = image.WithIfNoneMatch
+
+	// NewKeywordScreener builds a basic keyword/regex ContentFilter.
+	NewKeywordScreener = image.NewKeywordScreener
+
 	// SuccessCount returns successful results count.
 	SuccessCount = image.SuccessCount
 
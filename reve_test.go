@@ -17,6 +17,10 @@ import (
 	"github.com/shamspias/reve-go/types"
 )
 
+// onePixelPNG is a valid, minimal base64-encoded 1x1 PNG, for tests that
+// need a reference image ValidateReferenceImage accepts.
+const onePixelPNG = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAIAAACQd1PeAAAADElEQVR4nGP4z8AAAAMBAQDJ/pLvAAAAAElFTkSuQmCC"
+
 func TestNewClient(t *testing.T) {
 	client := reve.NewClient("test-key")
 	if client == nil {
@@ -171,8 +175,8 @@ func TestEditParamsValidation(t *testing.T) {
 		params  *image.EditParams
 		wantErr error
 	}{
-		{"valid", &image.EditParams{Instruction: "test", ReferenceImage: "base64"}, nil},
-		{"empty instruction", &image.EditParams{ReferenceImage: "base64"}, validator.ErrEmptyInstruction},
+		{"valid", &image.EditParams{Instruction: "test", ReferenceImage: onePixelPNG}, nil},
+		{"empty instruction", &image.EditParams{ReferenceImage: onePixelPNG}, validator.ErrEmptyInstruction},
 		{"empty image", &image.EditParams{Instruction: "test"}, validator.ErrEmptyReferenceImage},
 	}
 
@@ -192,8 +196,8 @@ func TestRemixParamsValidation(t *testing.T) {
 		params  *image.RemixParams
 		wantErr error
 	}{
-		{"valid", &image.RemixParams{Prompt: "test", ReferenceImages: []string{"img1"}}, nil},
-		{"empty prompt", &image.RemixParams{ReferenceImages: []string{"img1"}}, validator.ErrEmptyPrompt},
+		{"valid", &image.RemixParams{Prompt: "test", ReferenceImages: []string{onePixelPNG}}, nil},
+		{"empty prompt", &image.RemixParams{ReferenceImages: []string{onePixelPNG}}, validator.ErrEmptyPrompt},
 		{"no images", &image.RemixParams{Prompt: "test"}, validator.ErrNoReferenceImages},
 		{"too many", &image.RemixParams{Prompt: "test", ReferenceImages: make([]string, 7)}, validator.ErrTooManyReferenceImages},
 	}
@@ -243,6 +247,84 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestDraftMode(t *testing.T) {
+	var gotVersion string
+	var gotScaling float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body image.CreateParams
+		json.NewDecoder(r.Body).Decode(&body)
+		gotVersion = string(body.Version)
+		gotScaling = body.TestTimeScaling
+		json.NewEncoder(w).Encode(types.Result{Image: "base64data", Seed: 42})
+	}))
+	defer server.Close()
+
+	client := reve.NewClient("test-key", reve.WithBaseURL(server.URL), reve.WithNoRetry())
+
+	params := &image.CreateParams{
+		Prompt:      "test",
+		Postprocess: []types.Postprocess{types.Upscale(2)},
+		Draft:       true,
+	}
+	draft, err := client.Images.Create(context.Background(), params)
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if gotVersion != string(reve.VersionLatestFast) {
+		t.Errorf("draft Version = %s, want %s", gotVersion, reve.VersionLatestFast)
+	}
+	if gotScaling != 1 {
+		t.Errorf("draft TestTimeScaling = %v, want 1", gotScaling)
+	}
+
+	full, err := client.Images.Promote(context.Background(), draft, &image.CreateParams{
+		Prompt:      "test",
+		Postprocess: []types.Postprocess{types.Upscale(2)},
+	})
+	if err != nil {
+		t.Fatalf("Promote() error: %v", err)
+	}
+	if gotVersion == string(reve.VersionLatestFast) {
+		t.Errorf("promoted call still used fast model")
+	}
+	if full.Image != "base64data" {
+		t.Errorf("Image = %s, want base64data", full.Image)
+	}
+}
+
+func TestCreateWithProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := types.Result{Image: "base64data", CreditsUsed: 18}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := reve.NewClient("test-key", reve.WithBaseURL(server.URL), reve.WithNoRetry())
+
+	var phases []reve.Phase
+	result, err := client.Images.CreateWithProgress(context.Background(), &image.CreateParams{
+		Prompt: "test",
+	}, func(e reve.ProgressEvent) {
+		phases = append(phases, e.Phase)
+	})
+
+	if err != nil {
+		t.Fatalf("CreateWithProgress() error: %v", err)
+	}
+	if result.Image != "base64data" {
+		t.Errorf("Image = %s, want base64data", result.Image)
+	}
+	want := []reve.Phase{reve.PhaseQueued, reve.PhaseRequesting, reve.PhaseDone}
+	if len(phases) != len(want) {
+		t.Fatalf("phases = %v, want %v", phases, want)
+	}
+	for i, p := range phases {
+		if p != want[i] {
+			t.Errorf("phases[%d] = %v, want %v", i, p, want[i])
+		}
+	}
+}
+
 func TestAPIError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
@@ -294,6 +376,49 @@ func TestAPIErrorMethods(t *testing.T) {
 	}
 }
 
+func TestAPIErrorIsSentinel(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    *transport.APIError
+		target error
+		want   bool
+	}{
+		{"rate limited", &transport.APIError{Code: transport.ErrCodeRateLimit}, reve.ErrRateLimited, true},
+		{"insufficient credits", &transport.APIError{Code: transport.ErrCodeInsufficientFunds}, reve.ErrInsufficientCredits, true},
+		{"content violation", &transport.APIError{Code: transport.ErrCodeContentViolation}, reve.ErrContentViolation, true},
+		{"auth", &transport.APIError{Code: transport.ErrCodeInvalidAPIKey}, reve.ErrAuth, true},
+		{"mismatch", &transport.APIError{Code: transport.ErrCodeRateLimit}, reve.ErrAuth, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(error(tt.err), tt.target); got != tt.want {
+				t.Errorf("errors.Is(err, target) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReasonTypesImplementReason(t *testing.T) {
+	errs := []error{
+		transport.ErrCircuitOpen,
+		&transport.RetryBudgetExceededError{LastErr: errors.New("boom")},
+		&transport.DeadlineError{Remaining: time.Second, Margin: 2 * time.Second},
+		&transport.RateLimitShedError{},
+	}
+
+	for _, err := range errs {
+		var reason transport.Reason
+		if !errors.As(err, &reason) {
+			t.Errorf("errors.As(%T, &reason) = false, want true", err)
+			continue
+		}
+		if reason.Reason() == "" {
+			t.Errorf("%T.Reason() is empty", err)
+		}
+	}
+}
+
 func TestRetry(t *testing.T) {
 	attempts := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -324,6 +449,47 @@ func TestRetry(t *testing.T) {
 	}
 }
 
+func TestReload(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := reve.NewClient("test-key",
+		reve.WithBaseURL(server.URL),
+		reve.WithRetry(1, time.Millisecond, time.Millisecond),
+	)
+
+	_, err := client.Images.Create(context.Background(), &image.CreateParams{Prompt: "test"})
+	if err == nil {
+		t.Fatal("Create() error = nil, want error")
+	}
+	if attempts != 2 {
+		t.Fatalf("attempts = %d, want 2 (1 retry)", attempts)
+	}
+
+	client.Reload(&reve.ReloadConfig{
+		MaxRetries:   3,
+		RetryMinWait: time.Millisecond,
+		RetryMaxWait: time.Millisecond,
+	})
+
+	attempts = 0
+	_, err = client.Images.Create(context.Background(), &image.CreateParams{Prompt: "test"})
+	if err == nil {
+		t.Fatal("Create() error = nil, want error")
+	}
+	if attempts != 4 {
+		t.Errorf("attempts = %d, want 4 (3 retries) after Reload", attempts)
+	}
+
+	if got := client.Config().MaxRetries; got != 3 {
+		t.Errorf("Config().MaxRetries = %d, want 3 after Reload", got)
+	}
+}
+
 func TestCostEstimation(t *testing.T) {
 	cost := image.EstimateCreate(1, nil)
 	if cost.BaseCredits != 18 {
@@ -0,0 +1,251 @@
+// Package reveconfig loads client configuration from a config file and
+// the environment, so twelve-factor apps and CLIs don't have to
+// hand-wire every reve.Option at startup. Environment variables always
+// take precedence over a loaded file, letting an operator override one
+// setting (e.g. REVE_BASE_URL for a staging environment) without
+// touching a checked-in config file.
+package reveconfig
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	reve "github.com/shamspias/reve-go"
+	"github.com/shamspias/reve-go/types"
+)
+
+// Config is a client configuration loaded from a file, the environment,
+// or both. Zero-value fields are left at reve.NewClient's defaults.
+type Config struct {
+	APIKey       string
+	BaseURL      string
+	Proxy        string
+	Timeout      time.Duration
+	MaxRetries   int
+	RetryMinWait time.Duration
+	RetryMaxWait time.Duration
+
+	// DefaultVersion and DefaultAspectRatio are not applied
+	// automatically -- Create, Edit, and Remix take these per call, not
+	// per client -- but are parsed here so a caller can copy them onto
+	// every CreateParams/EditParams/RemixParams it builds:
+	//
+	//	params := &image.CreateParams{Prompt: prompt, Version: cfg.DefaultVersion}
+	DefaultVersion     types.ModelVersion
+	DefaultAspectRatio types.AspectRatio
+}
+
+// envPrefix is prepended to every environment variable Config reads.
+const envPrefix = "REVE_"
+
+// Load reads a Config from a file at path. The file is a flat subset of
+// YAML: one "key: value" pair per line, blank lines and lines starting
+// with "#" ignored. This is not a general YAML parser -- nested maps,
+// lists, and multi-document files aren't supported -- which keeps the
+// SDK free of a third-party YAML dependency for what is, in practice, a
+// flat settings file.
+//
+// Recognized keys: api_key, base_url, proxy, timeout, max_retries,
+// retry_min_wait, retry_max_wait, default_version, default_aspect_ratio.
+func Load(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cfg := &Config{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("reveconfig: %s: malformed line %q", path, line)
+		}
+		if err := cfg.set(strings.TrimSpace(key), unquote(strings.TrimSpace(value))); err != nil {
+			return nil, fmt.Errorf("reveconfig: %s: %w", path, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// LoadEnv reads a Config from REVE_-prefixed environment variables.
+func LoadEnv() (*Config, error) {
+	cfg := &Config{}
+	for _, key := range []string{
+		"api_key", "base_url", "proxy", "timeout",
+		"max_retries", "retry_min_wait", "retry_max_wait",
+		"default_version", "default_aspect_ratio",
+	} {
+		value, ok := os.LookupEnv(envPrefix + strings.ToUpper(key))
+		if !ok {
+			continue
+		}
+		if err := cfg.set(key, value); err != nil {
+			return nil, fmt.Errorf("reveconfig: %s%s: %w", envPrefix, strings.ToUpper(key), err)
+		}
+	}
+	return cfg, nil
+}
+
+// set assigns value to the field named by key (the file/env key, e.g.
+// "max_retries"), parsing it according to that field's type.
+func (c *Config) set(key, value string) error {
+	switch key {
+	case "api_key":
+		c.APIKey = value
+	case "base_url":
+		c.BaseURL = value
+	case "proxy":
+		c.Proxy = value
+	case "timeout":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		c.Timeout = d
+	case "max_retries":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		c.MaxRetries = n
+	case "retry_min_wait":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		c.RetryMinWait = d
+	case "retry_max_wait":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		c.RetryMaxWait = d
+	case "default_version":
+		c.DefaultVersion = types.ModelVersion(value)
+	case "default_aspect_ratio":
+		c.DefaultAspectRatio = types.AspectRatio(value)
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	return nil
+}
+
+// merge returns a Config with every non-zero field of override applied
+// on top of base.
+func merge(base, override *Config) *Config {
+	merged := *base
+	if override.APIKey != "" {
+		merged.APIKey = override.APIKey
+	}
+	if override.BaseURL != "" {
+		merged.BaseURL = override.BaseURL
+	}
+	if override.Proxy != "" {
+		merged.Proxy = override.Proxy
+	}
+	if override.Timeout != 0 {
+		merged.Timeout = override.Timeout
+	}
+	if override.MaxRetries != 0 {
+		merged.MaxRetries = override.MaxRetries
+	}
+	if override.RetryMinWait != 0 {
+		merged.RetryMinWait = override.RetryMinWait
+	}
+	if override.RetryMaxWait != 0 {
+		merged.RetryMaxWait = override.RetryMaxWait
+	}
+	if override.DefaultVersion != "" {
+		merged.DefaultVersion = override.DefaultVersion
+	}
+	if override.DefaultAspectRatio != "" {
+		merged.DefaultAspectRatio = override.DefaultAspectRatio
+	}
+	return &merged
+}
+
+// Options converts cfg into reve.Options for reve.NewClient.
+func (c *Config) Options() []reve.Option {
+	var opts []reve.Option
+	if c.BaseURL != "" {
+		opts = append(opts, reve.WithBaseURL(c.BaseURL))
+	}
+	if c.Timeout != 0 {
+		opts = append(opts, reve.WithTimeout(c.Timeout))
+	}
+	if c.MaxRetries != 0 || c.RetryMinWait != 0 || c.RetryMaxWait != 0 {
+		retry := *c
+		opts = append(opts, func(rc *reve.Config) {
+			if retry.MaxRetries != 0 {
+				rc.MaxRetries = retry.MaxRetries
+			}
+			if retry.RetryMinWait != 0 {
+				rc.RetryMinWait = retry.RetryMinWait
+			}
+			if retry.RetryMaxWait != 0 {
+				rc.RetryMaxWait = retry.RetryMaxWait
+			}
+		})
+	}
+	if c.Proxy != "" {
+		opts = append(opts, reve.WithHTTPProxy(c.Proxy))
+	}
+	return opts
+}
+
+// NewClientFromEnv builds a client entirely from REVE_-prefixed
+// environment variables. REVE_API_KEY is required.
+func NewClientFromEnv() (*reve.Client, error) {
+	cfg, err := LoadEnv()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("reveconfig: %sAPI_KEY is not set", envPrefix)
+	}
+	return reve.NewClient(cfg.APIKey, cfg.Options()...), nil
+}
+
+// NewClientFromConfig builds a client from the config file at path,
+// with any REVE_-prefixed environment variable overriding the
+// corresponding file setting.
+func NewClientFromConfig(path string) (*reve.Client, error) {
+	fileCfg, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	envCfg, err := LoadEnv()
+	if err != nil {
+		return nil, err
+	}
+	cfg := merge(fileCfg, envCfg)
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("reveconfig: no api_key in %s or %sAPI_KEY", path, envPrefix)
+	}
+	return reve.NewClient(cfg.APIKey, cfg.Options()...), nil
+}
+
+// unquote strips a single layer of matching quotes from a YAML-style
+// scalar value (e.g. `"http://host"` or 'http://host'), left as-is if
+// unquoted.
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' && last == '"') || (first == '\'' && last == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
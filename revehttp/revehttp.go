@@ -0,0 +1,79 @@
+// Package revehttp maps reve-go SDK errors onto HTTP responses, so a
+// service proxying Reve image generation to its own clients can return
+// consistent statuses and Retry-After headers instead of hand-rolling
+// the mapping at every call site.
+package revehttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/shamspias/reve-go/internal/transport"
+)
+
+// circuitOpenRetryAfter is suggested to callers when the local circuit
+// breaker is open, since the real wait is governed by
+// reve.WithCircuitBreaker's cooldown, which isn't visible here.
+const circuitOpenRetryAfter = 5 * time.Second
+
+// errorBody is the JSON shape WriteError writes to the response.
+type errorBody struct {
+	Error     string `json:"error"`
+	Code      string `json:"code,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// WriteError writes err to w as a JSON error response, mapping it to
+// the HTTP status (and, where applicable, Retry-After header) a
+// proxying service's own clients should see.
+//
+//   - *transport.APIError passes through the Reve API's own status
+//     code and Retry-After, if any.
+//   - transport.ErrCircuitOpen maps to 503 Service Unavailable with a
+//     short Retry-After, since the client is failing fast locally.
+//   - Anything else maps to 502 Bad Gateway.
+//
+// Example:
+//
+//	result, err := client.Images.Create(ctx, params)
+//	if err != nil {
+//		revehttp.WriteError(w, err)
+//		return
+//	}
+func WriteError(w http.ResponseWriter, err error) {
+	var apiErr *transport.APIError
+	if errors.As(err, &apiErr) {
+		writeJSON(w, apiErr.StatusCode, apiErr.RetryAfter, errorBody{
+			Error:     apiErr.Message,
+			Code:      string(apiErr.Code),
+			RequestID: apiErr.RequestID,
+		})
+		return
+	}
+
+	if errors.Is(err, transport.ErrCircuitOpen) {
+		writeJSON(w, http.StatusServiceUnavailable, circuitOpenRetryAfter, errorBody{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusBadGateway, 0, errorBody{
+		Error: err.Error(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, retryAfter time.Duration, body errorBody) {
+	if status == 0 {
+		status = http.StatusBadGateway
+	}
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter/time.Second)))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,75 @@
+// Package revetest provides test helpers for catching goroutine and
+// buffer leaks in long-running SDK usage. The SDK spawns goroutines for
+// hedged retries (internal/transport), batch workers (image.BatchCreate
+// and friends), queue.Queue, and download.Manager; a bug in any of them
+// not returning would otherwise only surface as an unexplained, slow
+// climb in a profiler months later, with no way to attribute the growth
+// back to a specific code path.
+package revetest
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// AssertNoLeaks snapshots the number of running goroutines, then
+// registers a t.Cleanup that re-checks it once the test finishes and
+// fails the test if the count is still higher than the snapshot. A
+// short grace period is given for goroutines mid-teardown (e.g. an idle
+// HTTP connection being returned to the pool) to exit before failing.
+//
+// Call it at the top of any test that exercises goroutine-spawning SDK
+// code:
+//
+//	func TestNoGoroutineLeak(t *testing.T) {
+//		revetest.AssertNoLeaks(t)
+//		... exercise the SDK ...
+//	}
+func AssertNoLeaks(t *testing.T) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		if after := stableGoroutineCount(before); after > before {
+			t.Errorf("goroutine leak: started with %d, ended with %d", before, after)
+		}
+	})
+}
+
+// stableGoroutineCount re-samples runtime.NumGoroutine up to 10 times
+// over 500ms, since a goroutine that is about to exit cleanly may still
+// be running at the instant Cleanup fires.
+func stableGoroutineCount(before int) int {
+	after := runtime.NumGoroutine()
+	for i := 0; i < 10 && after > before; i++ {
+		time.Sleep(50 * time.Millisecond)
+		after = runtime.NumGoroutine()
+	}
+	return after
+}
+
+// AssertNoLargeBuffers snapshots live heap bytes, then registers a
+// t.Cleanup that forces a GC and fails the test if heap allocation grew
+// by more than maxGrowth -- catching a held reference to a large
+// decoded image or response body that should have been released once
+// the request finished.
+//
+// This is advisory, not exact: GC pacing and unrelated allocations in
+// the same test can both move the number, so prefer a generous
+// maxGrowth and use it alongside, not instead of, a profiler when
+// chasing a real leak.
+func AssertNoLargeBuffers(t *testing.T, maxGrowth uint64) {
+	t.Helper()
+	var before runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	t.Cleanup(func() {
+		var after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		if after.HeapAlloc > before.HeapAlloc+maxGrowth {
+			t.Errorf("possible buffer leak: heap grew by %d bytes (limit %d)", after.HeapAlloc-before.HeapAlloc, maxGrowth)
+		}
+	})
+}
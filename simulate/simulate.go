@@ -0,0 +1,192 @@
+// Package simulate provides a local, zero-spend stand-in for the Reve
+// API: an http.RoundTripper that serves deterministic placeholder images
+// after a configurable delay, with a configurable chance of failing, so
+// application flows (including budgets and retries) can be exercised in
+// staging without touching the real API.
+package simulate
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/shamspias/reve-go/placeholder"
+	"github.com/shamspias/reve-go/types"
+)
+
+// requestBody is the subset of a Create/Edit/Remix request body the
+// simulated transport reads to render a placeholder that matches what
+// was actually asked for.
+type requestBody struct {
+	Prompt      string            `json:"prompt"`
+	Instruction string            `json:"instruction"`
+	AspectRatio types.AspectRatio `json:"aspect_ratio"`
+	Seed        int64             `json:"seed"`
+	NumImages   int               `json:"num_images"`
+}
+
+// readRequestBody reads req's body (restoring it afterward, since
+// http.RoundTripper must not consume it).
+func readRequestBody(req *http.Request) requestBody {
+	var body requestBody
+	if req.Body != nil {
+		raw, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(raw))
+		if err == nil {
+			_ = json.Unmarshal(raw, &body)
+		}
+	}
+	return body
+}
+
+// placeholderFor renders a placeholder image matching body's requested
+// aspect ratio, format, and prompt text.
+func placeholderFor(body requestBody, format types.OutputFormat) []byte {
+	label := body.Prompt
+	if label == "" {
+		label = body.Instruction
+	}
+
+	img, err := placeholder.Generate(placeholder.Options{
+		AspectRatio: body.AspectRatio,
+		Format:      format,
+		Label:       label,
+	})
+	if err != nil {
+		return nil
+	}
+	return img
+}
+
+// seedFor returns body's pinned seed, or a freshly rolled one when none
+// was given, so simulated results are reproducible exactly like the real
+// API when a caller sets CreateParams.Seed.
+func seedFor(body requestBody) int64 {
+	if body.Seed != 0 {
+		return body.Seed
+	}
+	return rand.Int64()
+}
+
+// Profile configures the simulated API's behavior.
+type Profile struct {
+	// Latency is how long every simulated call takes before responding.
+	Latency time.Duration
+
+	// FailureRate is the probability (0-1) that a call fails with a
+	// simulated INTERNAL_ERROR, to exercise retry and circuit-breaker
+	// logic.
+	FailureRate float64
+
+	// CreditCost is how many credits each successful call reports as
+	// consumed.
+	CreditCost int
+
+	// StartingCredits is the simulated account balance before any calls.
+	StartingCredits int
+}
+
+// Transport is an http.RoundTripper that serves Profile's behavior
+// instead of making real HTTP calls. Install it with reve.WithSimulation.
+type Transport struct {
+	profile   Profile
+	remaining atomic.Int64
+	requestNo atomic.Int64
+}
+
+// NewTransport creates a Transport from profile.
+func NewTransport(profile Profile) *Transport {
+	t := &Transport{profile: profile}
+	t.remaining.Store(int64(profile.StartingCredits))
+	return t
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.profile.Latency > 0 {
+		time.Sleep(t.profile.Latency)
+	}
+
+	n := t.requestNo.Add(1)
+	requestID := fmt.Sprintf("sim-%d", n)
+
+	if t.profile.FailureRate > 0 && rand.Float64() < t.profile.FailureRate {
+		return t.errorResponse(req, requestID), nil
+	}
+
+	remaining := t.remaining.Add(-int64(t.profile.CreditCost))
+	body := readRequestBody(req)
+
+	if accept := req.Header.Get("Accept"); accept != "" && accept != "application/json" {
+		return t.rawResponse(req, body, requestID, remaining, types.OutputFormat(accept)), nil
+	}
+	return t.jsonResponse(req, body, requestID, remaining), nil
+}
+
+func (t *Transport) errorResponse(req *http.Request, requestID string) *http.Response {
+	body, _ := json.Marshal(map[string]string{
+		"error_code": "INTERNAL_ERROR",
+		"message":    "simulated failure",
+	})
+	resp := newResponse(req, http.StatusInternalServerError, body)
+	resp.Header.Set("X-Reve-Request-Id", requestID)
+	return resp
+}
+
+func (t *Transport) jsonResponse(req *http.Request, body requestBody, requestID string, remaining int64) *http.Response {
+	n := body.NumImages
+	if n < 1 {
+		n = 1
+	}
+
+	envelope := map[string]any{
+		"version":           "simulated",
+		"content_violation": false,
+		"request_id":        requestID,
+		"credits_used":      t.profile.CreditCost,
+		"credits_remaining": remaining,
+		"seed":              seedFor(body),
+	}
+	if n == 1 {
+		envelope["image"] = base64.StdEncoding.EncodeToString(placeholderFor(body, types.FormatPNG))
+	} else {
+		images := make([]string, n)
+		for i := range images {
+			images[i] = base64.StdEncoding.EncodeToString(placeholderFor(body, types.FormatPNG))
+		}
+		envelope["images"] = images
+	}
+
+	respBody, _ := json.Marshal(envelope)
+	resp := newResponse(req, http.StatusOK, respBody)
+	resp.Header.Set("Content-Type", "application/json")
+	return resp
+}
+
+func (t *Transport) rawResponse(req *http.Request, body requestBody, requestID string, remaining int64, format types.OutputFormat) *http.Response {
+	img := placeholderFor(body, format)
+	resp := newResponse(req, http.StatusOK, img)
+	resp.Header.Set("Content-Type", format.ContentType())
+	resp.Header.Set("X-Reve-Version", "simulated")
+	resp.Header.Set("X-Reve-Request-Id", requestID)
+	resp.Header.Set("X-Reve-Credits-Used", fmt.Sprintf("%d", t.profile.CreditCost))
+	resp.Header.Set("X-Reve-Credits-Remaining", fmt.Sprintf("%d", remaining))
+	resp.Header.Set("X-Reve-Seed", fmt.Sprintf("%d", seedFor(body)))
+	return resp
+}
+
+func newResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}
+}
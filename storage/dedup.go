@@ -0,0 +1,109 @@
+// Package storage provides persistence helpers for generated images:
+// a content-addressed local Store, and the ObjectStore interface (with
+// bundled S3Store, GCSStore, and AzureBlobStore implementations) for
+// uploading results to a remote bucket instead of local disk.
+//
+// Store deduplicates results by content hash: identical output bytes
+// (common with seeded/deterministic batches) are written to disk once,
+// with additional references pointing at the same backing file.
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store is a content-addressed store for generated image bytes.
+type Store struct {
+	dir string
+
+	mu   sync.Mutex
+	refs map[string]int
+}
+
+// NewStore creates a Store rooted at dir. The directory is created on first
+// write if it does not already exist.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir, refs: make(map[string]int)}
+}
+
+// Hash returns the content address (hex SHA-256) for data.
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Put writes data under its content hash, returning the hash. If the
+// content already exists, no bytes are written and the reference count
+// is incremented.
+func (s *Store) Put(data []byte) (string, error) {
+	hash := Hash(data)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.refs[hash] > 0 {
+		s.refs[hash]++
+		return hash, nil
+	}
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return "", fmt.Errorf("storage: mkdir: %w", err)
+	}
+
+	path := s.path(hash)
+	if _, err := os.Stat(path); err == nil {
+		s.refs[hash]++
+		return hash, nil
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("storage: write: %w", err)
+	}
+	s.refs[hash] = 1
+	return hash, nil
+}
+
+// Get reads back the bytes stored under hash.
+func (s *Store) Get(hash string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(hash))
+	if err != nil {
+		return nil, fmt.Errorf("storage: read %s: %w", hash, err)
+	}
+	return data, nil
+}
+
+// RefCount returns how many times hash has been stored via Put.
+func (s *Store) RefCount(hash string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.refs[hash]
+}
+
+// Release decrements the reference count for hash and deletes the backing
+// file once it reaches zero.
+func (s *Store) Release(hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.refs[hash] == 0 {
+		return nil
+	}
+	s.refs[hash]--
+	if s.refs[hash] > 0 {
+		return nil
+	}
+	delete(s.refs, hash)
+	if err := os.Remove(s.path(hash)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("storage: remove %s: %w", hash, err)
+	}
+	return nil
+}
+
+func (s *Store) path(hash string) string {
+	return filepath.Join(s.dir, hash)
+}
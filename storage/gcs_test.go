@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"testing"
+)
+
+func TestSignRSASHA256RoundTripsWithPublicKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	sigHex, err := signRSASHA256(pemKey, "message-to-sign")
+	if err != nil {
+		t.Fatalf("signRSASHA256: %v", err)
+	}
+	if sigHex == "" {
+		t.Error("signRSASHA256 returned an empty signature")
+	}
+
+	sig, err := hex.DecodeString(sigHex)
+	if err != nil {
+		t.Fatalf("signature is not hex: %v", err)
+	}
+	hashed := sha256.Sum256([]byte("message-to-sign"))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hashed[:], sig); err != nil {
+		t.Errorf("signature does not verify with the public key: %v", err)
+	}
+}
+
+func TestSignRSASHA256RejectsInvalidPEM(t *testing.T) {
+	if _, err := signRSASHA256([]byte("not a pem block"), "message"); err == nil {
+		t.Error("signRSASHA256 with invalid PEM = nil error, want error")
+	}
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrKeyExists is returned by Put when opts.IfNoneMatch is set and an
+// object already exists at the given key.
+var ErrKeyExists = errors.New("storage: key already exists")
+
+// PutOptions describes how an object should be written by an
+// ObjectStore, mirroring the headers a generated image is typically
+// served behind (content type, caching, provenance tags).
+type PutOptions struct {
+	// ContentType is the object's MIME type, e.g. "image/png".
+	ContentType string
+
+	// CacheControl is the Cache-Control header to serve the object
+	// with, if the backend supports one.
+	CacheControl string
+
+	// Metadata is arbitrary key/value provenance to attach to the
+	// object (e.g. request ID, model version), stored as backend-native
+	// object metadata where supported.
+	Metadata map[string]string
+
+	// IfNoneMatch, when true, makes Put an atomic create-only write: it
+	// fails with ErrKeyExists if an object already exists at key,
+	// instead of overwriting it. Lets parallel batch workers writing to
+	// the same bucket/prefix detect a key collision instead of silently
+	// clobbering each other's output.
+	IfNoneMatch bool
+}
+
+// ObjectStore uploads image bytes to remote object storage, for
+// callers that want generated results to land directly in a bucket
+// instead of on local disk. See types.Result.SaveToStore and the
+// bundled S3Store, GCSStore, and AzureBlobStore implementations.
+type ObjectStore interface {
+	// Put uploads data under key, applying opts.
+	Put(ctx context.Context, key string, data []byte, opts PutOptions) error
+}
+
+// URLSigner is implemented by ObjectStore backends that can mint a
+// time-limited download URL for a previously-uploaded key, for handing
+// a short-lived link to an untrusted client without sharing backend
+// credentials. See GCSStore.SignedURL and AzureBlobStore.SignedURL.
+type URLSigner interface {
+	SignedURL(ctx context.Context, key string, expires time.Duration) (string, error)
+}
+
+// DefaultCacheControl is a sane Cache-Control value for generated
+// images: the bytes at a given key never change once written, so
+// callers can cache them indefinitely.
+const DefaultCacheControl = "public, max-age=31536000, immutable"
+
+var (
+	pngSignature = []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}
+	jpegSOI      = []byte{0xFF, 0xD8}
+)
+
+// DetectContentType returns data's MIME type by magic bytes, falling
+// back to "application/octet-stream" if it's neither a PNG nor a JPEG.
+func DetectContentType(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, pngSignature):
+		return "image/png"
+	case bytes.HasPrefix(data, jpegSOI):
+		return "image/jpeg"
+	default:
+		return "application/octet-stream"
+	}
+}
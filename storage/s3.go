@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Config configures an S3Store. No AWS SDK is required: requests are
+// signed with AWS Signature Version 4 using only the standard library,
+// matching the SDK-free style of the rest of this module.
+type S3Config struct {
+	// Bucket is the destination bucket name.
+	Bucket string
+
+	// Region is the AWS region the bucket lives in, e.g. "us-east-1".
+	Region string
+
+	// AccessKeyID and SecretAccessKey are the credentials used to sign
+	// requests.
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// SessionToken is an optional STS session token, sent as
+	// X-Amz-Security-Token when set.
+	SessionToken string
+
+	// Endpoint overrides the default
+	// "https://<bucket>.s3.<region>.amazonaws.com" host, for
+	// S3-compatible backends (e.g. MinIO, R2). The key is appended
+	// directly to Endpoint; Bucket is not added to it.
+	Endpoint string
+
+	// HTTPClient is the client used to issue requests. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// S3Store is an ObjectStore backed by Amazon S3 (or an S3-compatible
+// backend). Install results on it with types.Result.SaveToStore.
+type S3Store struct {
+	cfg S3Config
+}
+
+// NewS3Store creates an S3Store from cfg.
+func NewS3Store(cfg S3Config) *S3Store {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	return &S3Store{cfg: cfg}
+}
+
+// Put implements ObjectStore, uploading data to key via a SigV4-signed
+// PUT request.
+func (s *S3Store) Put(ctx context.Context, key string, data []byte, opts PutOptions) error {
+	endpoint := s.cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", s.cfg.Bucket, s.cfg.Region)
+	}
+	reqURL := strings.TrimRight(endpoint, "/") + "/" + url.PathEscape(key)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, reqURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("storage: new request: %w", err)
+	}
+
+	if opts.ContentType != "" {
+		req.Header.Set("Content-Type", opts.ContentType)
+	}
+	if opts.CacheControl != "" {
+		req.Header.Set("Cache-Control", opts.CacheControl)
+	}
+	for k, v := range opts.Metadata {
+		req.Header.Set("X-Amz-Meta-"+k, v)
+	}
+	if s.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.cfg.SessionToken)
+	}
+	if opts.IfNoneMatch {
+		req.Header.Set("If-None-Match", "*")
+	}
+
+	if err := signV4(req, data, s.cfg.Region, "s3", s.cfg.AccessKeyID, s.cfg.SecretAccessKey, time.Now().UTC()); err != nil {
+		return fmt.Errorf("storage: sign request: %w", err)
+	}
+
+	resp, err := s.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("storage: put %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if opts.IfNoneMatch && resp.StatusCode == http.StatusPreconditionFailed {
+		return ErrKeyExists
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("storage: put %s: unexpected status %s", key, resp.Status)
+	}
+	return nil
+}
+
+var _ ObjectStore = (*S3Store)(nil)
+
+// signV4 signs req in place with AWS Signature Version 4, following the
+// canonical request / string-to-sign / signing-key recipe described at
+// https://docs.aws.amazon.com/general/latest/gr/sigv4-signed-request.html.
+func signV4(req *http.Request, body []byte, region, service, accessKeyID, secretAccessKey string, now time.Time) error {
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Set("Host", req.URL.Host)
+
+	headerNames := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		headerNames = append(headerNames, strings.ToLower(name))
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name)
+		canonicalHeaders.WriteByte(':')
+		canonicalHeaders.WriteString(strings.TrimSpace(req.Header.Get(name)))
+		canonicalHeaders.WriteByte('\n')
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL.Path),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	credentialScope := fmt.Sprintf("%s/%s/%s/aws4_request", dateStamp, region, service)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := signingKeyV4(secretAccessKey, dateStamp, region, service)
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, credentialScope, signedHeaders, signature,
+	))
+	return nil
+}
+
+func canonicalURI(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func signingKeyV4(secretAccessKey, dateStamp, region, service string) []byte {
+	kDate := hmacSHA256([]byte("AWS4"+secretAccessKey), dateStamp)
+	kRegion := hmacSHA256(kDate, region)
+	kService := hmacSHA256(kRegion, service)
+	return hmacSHA256(kService, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
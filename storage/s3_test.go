@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignV4SetsAuthorizationHeader(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPut, "https://my-bucket.s3.us-east-1.amazonaws.com/key", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := signV4(req, []byte("payload"), "us-east-1", "s3", "AKIDEXAMPLE", "secret", now); err != nil {
+		t.Fatalf("signV4: %v", err)
+	}
+
+	auth := req.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240102/us-east-1/s3/aws4_request") {
+		t.Errorf("Authorization = %q, want AWS4-HMAC-SHA256 credential prefix", auth)
+	}
+	if req.Header.Get("X-Amz-Date") != "20240102T030405Z" {
+		t.Errorf("X-Amz-Date = %q, want 20240102T030405Z", req.Header.Get("X-Amz-Date"))
+	}
+}
+
+func TestSignV4IsDeterministicForSameInputs(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	newReq := func() *http.Request {
+		req, _ := http.NewRequest(http.MethodPut, "https://my-bucket.s3.us-east-1.amazonaws.com/key", nil)
+		return req
+	}
+
+	req1 := newReq()
+	if err := signV4(req1, []byte("payload"), "us-east-1", "s3", "AKIDEXAMPLE", "secret", now); err != nil {
+		t.Fatalf("signV4: %v", err)
+	}
+	req2 := newReq()
+	if err := signV4(req2, []byte("payload"), "us-east-1", "s3", "AKIDEXAMPLE", "secret", now); err != nil {
+		t.Fatalf("signV4: %v", err)
+	}
+
+	if req1.Header.Get("Authorization") != req2.Header.Get("Authorization") {
+		t.Error("signV4 produced different signatures for identical inputs")
+	}
+}
+
+func TestSignV4ChangesWithSecret(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	newReq := func() *http.Request {
+		req, _ := http.NewRequest(http.MethodPut, "https://my-bucket.s3.us-east-1.amazonaws.com/key", nil)
+		return req
+	}
+
+	req1 := newReq()
+	if err := signV4(req1, []byte("payload"), "us-east-1", "s3", "AKIDEXAMPLE", "secret-one", now); err != nil {
+		t.Fatalf("signV4: %v", err)
+	}
+	req2 := newReq()
+	if err := signV4(req2, []byte("payload"), "us-east-1", "s3", "AKIDEXAMPLE", "secret-two", now); err != nil {
+		t.Fatalf("signV4: %v", err)
+	}
+
+	if req1.Header.Get("Authorization") == req2.Header.Get("Authorization") {
+		t.Error("signV4 produced the same signature for two different secret keys")
+	}
+}
+
+func TestCanonicalURIDefaultsToRoot(t *testing.T) {
+	if got := canonicalURI(""); got != "/" {
+		t.Errorf("canonicalURI(\"\") = %q, want /", got)
+	}
+	if got := canonicalURI("/a/b"); got != "/a/b" {
+		t.Errorf("canonicalURI(\"/a/b\") = %q, want /a/b", got)
+	}
+}
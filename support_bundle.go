@@ -0,0 +1,127 @@
+package reve
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/shamspias/reve-go/internal/transport"
+)
+
+// supportBundleHistoryLimit bounds the in-memory transcript kept for
+// SupportBundle; older entries are dropped as new ones arrive.
+const supportBundleHistoryLimit = 200
+
+// supportBundleErrorLimit is the number of most recent failed attempts
+// included as errors.json.
+const supportBundleErrorLimit = 20
+
+// historyBuffer is a fixed-capacity ring of recent request attempts,
+// fed by transport.Client via Config.HistoryRecorder.
+type historyBuffer struct {
+	mu      sync.Mutex
+	entries []transport.HistoryEntry
+	limit   int
+}
+
+func newHistoryBuffer(limit int) *historyBuffer {
+	return &historyBuffer{limit: limit}
+}
+
+func (h *historyBuffer) record(entry transport.HistoryEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = append(h.entries, entry)
+	if over := len(h.entries) - h.limit; over > 0 {
+		h.entries = h.entries[over:]
+	}
+}
+
+func (h *historyBuffer) recent() []transport.HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]transport.HistoryEntry(nil), h.entries...)
+}
+
+func (h *historyBuffer) recentErrors(limit int) []transport.HistoryEntry {
+	var errs []transport.HistoryEntry
+	for _, e := range h.recent() {
+		if e.Err != "" {
+			errs = append(errs, e)
+		}
+	}
+	if over := len(errs) - limit; over > 0 {
+		errs = errs[over:]
+	}
+	return errs
+}
+
+// redactedConfig is the subset of Config safe to attach to a support
+// ticket: every credential-bearing field is stripped or reduced to a
+// boolean "was it set" flag.
+type redactedConfig struct {
+	BaseURL          string `json:"base_url"`
+	Timeout          string `json:"timeout"`
+	MaxRetries       int    `json:"max_retries"`
+	UserAgent        string `json:"user_agent"`
+	Debug            bool   `json:"debug"`
+	HasAPIKey        bool   `json:"has_api_key"`
+	HasKeyProvider   bool   `json:"has_key_provider"`
+	CircuitThreshold int    `json:"circuit_threshold"`
+	HedgeMaxHedges   int    `json:"hedge_max_hedges"`
+}
+
+func (c *Config) redact() redactedConfig {
+	return redactedConfig{
+		BaseURL:          c.BaseURL,
+		Timeout:          c.Timeout.String(),
+		MaxRetries:       c.MaxRetries,
+		UserAgent:        c.UserAgent,
+		Debug:            c.Debug,
+		HasAPIKey:        c.APIKey != "",
+		HasKeyProvider:   c.keyProvider != nil,
+		CircuitThreshold: c.CircuitThreshold,
+		HedgeMaxHedges:   c.HedgeMaxHedges,
+	}
+}
+
+// SupportBundle writes a zip archive to w containing redacted config, a
+// recent request transcript, the last N failed attempts, and SDK version
+// info, standardizing what to attach to a Reve support ticket.
+//
+// Example:
+//
+//	f, _ := os.Create("support-bundle.zip")
+//	defer f.Close()
+//	err := client.SupportBundle(ctx, f)
+func (c *Client) SupportBundle(ctx context.Context, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	if err := writeZIPJSON(zw, "version.json", map[string]string{
+		"sdk_version": Version,
+	}); err != nil {
+		return err
+	}
+	if err := writeZIPJSON(zw, "config.json", c.config.redact()); err != nil {
+		return err
+	}
+	if err := writeZIPJSON(zw, "transcript.json", c.history.recent()); err != nil {
+		return err
+	}
+	if err := writeZIPJSON(zw, "errors.json", c.history.recentErrors(supportBundleErrorLimit)); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func writeZIPJSON(zw *zip.Writer, name string, v any) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(f).Encode(v)
+}
@@ -0,0 +1,47 @@
+package types
+
+// Generation is implemented by both Result and RawResult, so downstream
+// code that doesn't care whether a request went through the JSON or raw
+// path can work with either via one interface.
+type Generation interface {
+	// Bytes returns the raw image bytes.
+	Bytes() ([]byte, error)
+
+	// SaveTo saves the image to a file.
+	SaveTo(path string) error
+
+	// ID returns the unique request identifier.
+	ID() string
+
+	// ModelVersion returns the model version used.
+	ModelVersion() string
+
+	// Credits returns the credits used by this request and the credits
+	// remaining on the account afterward.
+	Credits() (used, remaining int)
+}
+
+// ID returns the unique request identifier.
+func (r *Result) ID() string { return r.RequestID }
+
+// ModelVersion returns the model version used.
+func (r *Result) ModelVersion() string { return r.Version }
+
+// Credits returns the credits used by this request and the credits
+// remaining on the account afterward.
+func (r *Result) Credits() (used, remaining int) { return r.CreditsUsed, r.CreditsRemaining }
+
+// ID returns the unique request identifier.
+func (r *RawResult) ID() string { return r.RequestID }
+
+// ModelVersion returns the model version used.
+func (r *RawResult) ModelVersion() string { return r.Version }
+
+// Credits returns the credits used by this request and the credits
+// remaining on the account afterward.
+func (r *RawResult) Credits() (used, remaining int) { return r.CreditsUsed, r.CreditsRemaining }
+
+var (
+	_ Generation = (*Result)(nil)
+	_ Generation = (*RawResult)(nil)
+)
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/base64"
 	"fmt"
+	"io"
 	"os"
 )
 
@@ -10,6 +11,7 @@ import (
 type Image struct {
 	data   []byte
 	base64 string
+	reader io.Reader
 }
 
 // NewImage creates an Image from raw bytes.
@@ -44,8 +46,34 @@ func NewImageFromFile(path string) (*Image, error) {
 	return &Image{data: data}, nil
 }
 
-// Bytes returns the raw image bytes.
+// NewImageFromReader creates an Image that reads from r the first time
+// its bytes are actually needed (Bytes, Base64, SaveTo, or Size),
+// rather than up front, so callers can pass e.g. an S3 object reader
+// without loading it into memory before knowing whether the image will
+// be used. r is read at most once; the result is cached for later
+// calls.
+//
+// Example:
+//
+//	obj, _ := s3Client.GetObject(ctx, bucket, key)
+//	img := types.NewImageFromReader(obj.Body)
+func NewImageFromReader(r io.Reader) *Image {
+	return &Image{reader: r}
+}
+
+// Bytes returns the raw image bytes, reading from the underlying
+// io.Reader on first call if the Image was created with
+// NewImageFromReader.
 func (img *Image) Bytes() ([]byte, error) {
+	if img.reader != nil {
+		data, err := io.ReadAll(img.reader)
+		if err != nil {
+			return nil, fmt.Errorf("read image: %w", err)
+		}
+		img.data = data
+		img.reader = nil
+		return img.data, nil
+	}
 	if len(img.data) > 0 {
 		return img.data, nil
 	}
@@ -55,12 +83,19 @@ func (img *Image) Bytes() ([]byte, error) {
 	return nil, fmt.Errorf("image is empty")
 }
 
-// Base64 returns the base64 encoded image.
+// Base64 returns the base64 encoded image. For an Image created with
+// NewImageFromReader, this reads the underlying reader on first call;
+// if that read fails, Base64 returns an empty string -- use Bytes
+// directly when the read error needs to be handled.
 func (img *Image) Base64() string {
 	if img.base64 != "" {
 		return img.base64
 	}
-	return base64.StdEncoding.EncodeToString(img.data)
+	data, err := img.Bytes()
+	if err != nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(data)
 }
 
 // SaveTo saves the image to a file.
@@ -72,8 +107,16 @@ func (img *Image) SaveTo(path string) error {
 	return os.WriteFile(path, data, 0644)
 }
 
-// Size returns the size in bytes.
+// Size returns the size in bytes. For an Image created with
+// NewImageFromReader, this reads the underlying reader on first call.
 func (img *Image) Size() int {
+	if img.reader != nil {
+		data, err := img.Bytes()
+		if err != nil {
+			return 0
+		}
+		return len(data)
+	}
 	if len(img.data) > 0 {
 		return len(img.data)
 	}
@@ -1,8 +1,17 @@
 package types
 
 import (
+	"context"
 	"encoding/base64"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shamspias/reve-go/metadata"
+	"github.com/shamspias/reve-go/storage"
 )
 
 // Result represents an image generation result.
@@ -24,6 +33,27 @@ type Result struct {
 
 	// CreditsRemaining is the remaining credits.
 	CreditsRemaining int `json:"credits_remaining"`
+
+	// Seed is the random seed actually used, echoing back CreateParams.Seed
+	// when one was given, or reporting the model-chosen seed otherwise, so
+	// a result can be reproduced later.
+	Seed int64 `json:"seed"`
+
+	// Index is this image's position within a MultiResult (see
+	// image.CreateParams.NumImages); zero for single-image responses.
+	Index int `json:"-"`
+
+	// Region is the data-residency region the request was routed to,
+	// set by reve.WithRegion; empty when no region was configured.
+	Region string `json:"-"`
+
+	// AttemptCount is how many HTTP attempts the transport made to get
+	// this result, including the first, before it succeeded.
+	AttemptCount int `json:"-"`
+
+	// Latency is the total wall-clock time the transport spent on this
+	// call, across every attempt.
+	Latency time.Duration `json:"-"`
 }
 
 // Bytes returns the raw image bytes.
@@ -45,6 +75,105 @@ func (r *Result) SaveTo(path string) error {
 	return os.WriteFile(path, data, 0644)
 }
 
+// SaveToWithMetadata saves the image to path with meta embedded as
+// provenance (a PNG tEXt chunk or JPEG COM segment, see package
+// metadata), so the saved file carries its own generation parameters
+// for later auditing.
+//
+// Example:
+//
+//	result, _ := client.Images.Create(ctx, params)
+//	err := result.SaveToWithMetadata("output.png", metadata.Metadata{
+//		Prompt:    params.Prompt,
+//		Model:     result.Version,
+//		RequestID: result.RequestID,
+//		Seed:      result.Seed,
+//		CreatedAt: time.Now(),
+//	})
+func (r *Result) SaveToWithMetadata(path string, meta metadata.Metadata) error {
+	data, err := r.Bytes()
+	if err != nil {
+		return err
+	}
+
+	embedded, err := metadata.Embed(data, meta)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, embedded, 0644)
+}
+
+// SaveToStore uploads the image to store under key, instead of the
+// local disk, for callers that want generated results to land directly
+// in a bucket. The content type is detected from the image's magic
+// bytes; opts.ContentType, if set, overrides it.
+//
+// Example:
+//
+//	result, _ := client.Images.Create(ctx, params)
+//	err := result.SaveToStore(ctx, s3Store, "outputs/sunset.png", storage.PutOptions{})
+func (r *Result) SaveToStore(ctx context.Context, store storage.ObjectStore, key string, opts storage.PutOptions) error {
+	data, err := r.Bytes()
+	if err != nil {
+		return err
+	}
+	if opts.ContentType == "" {
+		opts.ContentType = storage.DetectContentType(data)
+	}
+	return store.Put(ctx, key, data, opts)
+}
+
+// MultiResult holds every image returned from a single request that set
+// image.CreateParams.NumImages greater than 1. See image.Service.CreateMulti.
+//
+// A response can be partial: the server may return an empty or
+// malformed payload for one candidate (e.g. it was blocked by content
+// policy) while the rest generated normally. MultiResult surfaces that
+// as Errors instead of failing CreateMulti outright, so a caller can
+// still use the images that did come through.
+type MultiResult struct {
+	// Results holds one Result per image the server returned
+	// successfully, in server order, each with Index set to its
+	// original position in the response.
+	Results []Result
+
+	// Errors holds one ImageError per image the server returned but
+	// that failed to decode. Empty when every requested image
+	// generated successfully.
+	Errors []ImageError
+}
+
+// ImageError describes one image of a MultiResult response that failed
+// to decode.
+type ImageError struct {
+	// Index is the image's position in the original response.
+	Index int
+
+	// Err is the decode failure.
+	Err error
+}
+
+func (e *ImageError) Error() string {
+	return fmt.Sprintf("image %d: %v", e.Index, e.Err)
+}
+
+func (e *ImageError) Unwrap() error { return e.Err }
+
+// SaveAllTo saves every result in m to dir, one file per image, named by
+// substituting the image's index for pattern's "*"
+// (e.g. pattern "candidate_*.png" writes "candidate_0.png", "candidate_1.png", ...).
+func (m *MultiResult) SaveAllTo(dir, pattern string) error {
+	for i := range m.Results {
+		r := &m.Results[i]
+		name := strings.Replace(pattern, "*", strconv.Itoa(r.Index), 1)
+		if err := r.SaveTo(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("save image %d: %w", r.Index, err)
+		}
+	}
+	return nil
+}
+
 // RawResult represents a raw binary response.
 type RawResult struct {
 	// Data is the raw image bytes.
@@ -67,6 +196,35 @@ type RawResult struct {
 
 	// CreditsRemaining is the remaining credits.
 	CreditsRemaining int
+
+	// ETag identifies this result's content for a future If-None-Match
+	// revalidation (see image.WithIfNoneMatch).
+	ETag string
+
+	// NotModified is true when this result was produced from a 304 Not
+	// Modified response to a request carrying If-None-Match; Data is
+	// empty and the caller should keep using its cached copy.
+	NotModified bool
+
+	// Seed is the random seed actually used. See Result.Seed.
+	Seed int64
+
+	// Region is the data-residency region the request was routed to.
+	// See Result.Region.
+	Region string
+
+	// AttemptCount is how many HTTP attempts the transport made to get
+	// this result. See Result.AttemptCount.
+	AttemptCount int
+
+	// Latency is the total wall-clock time the transport spent on this
+	// call. See Result.Latency.
+	Latency time.Duration
+}
+
+// Bytes returns the raw image bytes.
+func (r *RawResult) Bytes() ([]byte, error) {
+	return r.Data, nil
 }
 
 // SaveTo saves the raw image to a file.
@@ -74,6 +232,15 @@ func (r *RawResult) SaveTo(path string) error {
 	return os.WriteFile(path, r.Data, 0644)
 }
 
+// SaveToStore uploads the raw image to store under key. See
+// Result.SaveToStore.
+func (r *RawResult) SaveToStore(ctx context.Context, store storage.ObjectStore, key string, opts storage.PutOptions) error {
+	if opts.ContentType == "" {
+		opts.ContentType = r.ContentType
+	}
+	return store.Put(ctx, key, r.Data, opts)
+}
+
 // Size returns the size in bytes.
 func (r *RawResult) Size() int {
 	return len(r.Data)
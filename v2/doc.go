@@ -0,0 +1,30 @@
+// Package reve is the scaffold for reve-go v2.
+//
+// v1 (github.com/shamspias/reve-go) keeps its error types under
+// internal/transport, which the Go toolchain makes unreachable outside
+// this module -- callers can type-assert against *transport.APIError
+// only because examples and internal packages live inside the same
+// module root. v2 promotes those types to a public package
+// (github.com/shamspias/reve-go/v2/errors) so external callers get the
+// same errors.As-based handling v1's own code already uses.
+//
+// That promotion is the only piece this module implements so far. Two
+// further changes are planned but NOT yet done, and are listed here so
+// the scope of "v2" doesn't silently drift:
+//
+//   - context-first interfaces: v1's Service methods take ctx as the
+//     first parameter already (e.g. Create(ctx, params, ...opts)), and
+//     so do Cache and Fallback, but some supporting function types
+//     (ContentFilter, PromptScrubber) predate that convention and take
+//     no context at all. v2 will add ctx to each.
+//   - no string-typed image fields: CreateParams.ReferenceImage et al.
+//     carry images as base64 strings; v2 will change these to a typed
+//     Image value (see v1's types.Image, which already wraps this) so
+//     the compiler catches a raw string passed where an encoded image
+//     is expected.
+//
+// Until both land, v2 is not a drop-in replacement for v1 -- it
+// currently only hosts the promoted error package. See the migration
+// package (github.com/shamspias/reve-go/migration, in the v1 module)
+// for converting a v1 error into its v2 shape incrementally.
+package reve
@@ -0,0 +1,124 @@
+// Package errors holds the public promotion of v1's
+// internal/transport error types, so code outside the reve-go module
+// can use errors.As against them directly instead of being blocked by
+// Go's internal/ import rule. See the v2 package doc for what else v2
+// does and doesn't cover yet.
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrorCode identifies a specific API error condition.
+type ErrorCode string
+
+// Known error codes.
+const (
+	ErrCodeMissingParam      ErrorCode = "MISSING_REQUIRED_PARAMETER"
+	ErrCodePromptTooLong     ErrorCode = "PROMPT_TOO_LONG"
+	ErrCodeContentViolation  ErrorCode = "CONTENT_POLICY_VIOLATION"
+	ErrCodeIndexOutOfBounds  ErrorCode = "INDEX_OUT_OF_BOUNDS"
+	ErrCodeInvalidAPIKey     ErrorCode = "INVALID_API_KEY"
+	ErrCodeInsufficientFunds ErrorCode = "INSUFFICIENT_CREDITS"
+	ErrCodeRateLimit         ErrorCode = "RATE_LIMIT_EXCEEDED"
+	ErrCodeInternal          ErrorCode = "INTERNAL_ERROR"
+)
+
+// APIError represents an error response from the Reve API. It is the
+// public counterpart of v1's *transport.APIError -- same fields, same
+// semantics -- promoted so external packages can name the type.
+type APIError struct {
+	Code       ErrorCode      `json:"error_code"`
+	Message    string         `json:"message"`
+	Params     map[string]any `json:"params,omitempty"`
+	StatusCode int            `json:"-"`
+	RequestID  string         `json:"-"`
+
+	// RetryAfter is how long the API asked callers to wait before
+	// retrying, parsed from the Retry-After header. Zero when the
+	// response didn't include one.
+	RetryAfter time.Duration `json:"-"`
+
+	// RequestBody is a redacted copy of the request that produced this
+	// error, present only when the client captures error bodies. Image
+	// payload fields are replaced with their size so the body stays
+	// log-safe.
+	RequestBody map[string]any `json:"-"`
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("reve: %s (code=%s, status=%d, request_id=%s)",
+			e.Message, e.Code, e.StatusCode, e.RequestID)
+	}
+	return fmt.Sprintf("reve: %s (code=%s, status=%d)", e.Message, e.Code, e.StatusCode)
+}
+
+// Retryable returns true if the error can be retried.
+func (e *APIError) Retryable() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// IsRateLimit returns true if rate limited.
+func (e *APIError) IsRateLimit() bool {
+	return e.Code == ErrCodeRateLimit || e.StatusCode == http.StatusTooManyRequests
+}
+
+// IsInsufficientFunds returns true if insufficient credits.
+func (e *APIError) IsInsufficientFunds() bool {
+	return e.Code == ErrCodeInsufficientFunds || e.StatusCode == http.StatusPaymentRequired
+}
+
+// IsContentViolation returns true if content policy violated.
+func (e *APIError) IsContentViolation() bool {
+	return e.Code == ErrCodeContentViolation
+}
+
+// IsAuthError returns true if authentication failed.
+func (e *APIError) IsAuthError() bool {
+	return e.Code == ErrCodeInvalidAPIKey || e.StatusCode == http.StatusUnauthorized
+}
+
+// RequestError represents a request-level error -- one that happened
+// building or sending the request, rather than an error response from
+// the API.
+type RequestError struct {
+	Op  string
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("reve: %s: %v", e.Op, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// FromV1 converts a v1 *transport.APIError into its v2 shape. Callers
+// on v1 who want to start handling errors through this package during
+// a gradual migration should use the migration package
+// (github.com/shamspias/reve-go/migration) instead of depending on v2's
+// internals directly.
+func FromV1(code, message string, statusCode int, requestID string, retryAfter time.Duration) *APIError {
+	return &APIError{
+		Code:       ErrorCode(code),
+		Message:    message,
+		StatusCode: statusCode,
+		RequestID:  requestID,
+		RetryAfter: retryAfter,
+	}
+}
@@ -0,0 +1,148 @@
+// Package webhook provides helpers for receiving and verifying Reve callback events.
+//
+// Reve can notify applications about generation lifecycle events (completion,
+// failure) via HTTP callbacks. This package verifies the request signature,
+// decodes the payload into typed events, and dispatches to user callbacks.
+//
+// # Usage
+//
+//	handler := webhook.NewHandler("whsec_...", webhook.Callbacks{
+//		OnCompleted: func(ctx context.Context, e *webhook.GenerationCompleted) error {
+//			log.Printf("generation %s completed", e.RequestID)
+//			return nil
+//		},
+//		OnFailed: func(ctx context.Context, e *webhook.GenerationFailed) error {
+//			log.Printf("generation %s failed: %s", e.RequestID, e.Message)
+//			return nil
+//		},
+//	})
+//	http.Handle("/webhooks/reve", handler)
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Header names used by Reve webhook deliveries.
+const (
+	HeaderSignature = "X-Reve-Signature"
+	HeaderEventType = "X-Reve-Event-Type"
+)
+
+// EventType identifies the kind of webhook payload.
+type EventType string
+
+// Supported event types.
+const (
+	EventGenerationCompleted EventType = "generation.completed"
+	EventGenerationFailed    EventType = "generation.failed"
+)
+
+// GenerationCompleted is emitted when an async generation finishes successfully.
+type GenerationCompleted struct {
+	RequestID        string `json:"request_id"`
+	Version          string `json:"version"`
+	Image            string `json:"image"`
+	CreditsUsed      int    `json:"credits_used"`
+	CreditsRemaining int    `json:"credits_remaining"`
+}
+
+// GenerationFailed is emitted when an async generation fails.
+type GenerationFailed struct {
+	RequestID string `json:"request_id"`
+	Code      string `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// Callbacks holds the user-supplied handlers dispatched by Handler.
+//
+// A nil callback for an event type causes that event to be acknowledged
+// (200 OK) without further action.
+type Callbacks struct {
+	OnCompleted func(ctx context.Context, e *GenerationCompleted) error
+	OnFailed    func(ctx context.Context, e *GenerationFailed) error
+}
+
+// Handler verifies and dispatches incoming Reve webhook deliveries.
+type Handler struct {
+	secret    string
+	callbacks Callbacks
+}
+
+// NewHandler creates a webhook Handler that verifies deliveries using secret
+// and dispatches decoded events to callbacks.
+func NewHandler(secret string, callbacks Callbacks) *Handler {
+	return &Handler{secret: secret, callbacks: callbacks}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "read body", http.StatusBadRequest)
+		return
+	}
+
+	sig := r.Header.Get(HeaderSignature)
+	if err := VerifySignature(h.secret, body, sig); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	eventType := EventType(r.Header.Get(HeaderEventType))
+	if err := h.dispatch(r.Context(), eventType, body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) dispatch(ctx context.Context, eventType EventType, body []byte) error {
+	switch eventType {
+	case EventGenerationCompleted:
+		if h.callbacks.OnCompleted == nil {
+			return nil
+		}
+		var e GenerationCompleted
+		if err := json.Unmarshal(body, &e); err != nil {
+			return fmt.Errorf("webhook: decode %s: %w", eventType, err)
+		}
+		return h.callbacks.OnCompleted(ctx, &e)
+	case EventGenerationFailed:
+		if h.callbacks.OnFailed == nil {
+			return nil
+		}
+		var e GenerationFailed
+		if err := json.Unmarshal(body, &e); err != nil {
+			return fmt.Errorf("webhook: decode %s: %w", eventType, err)
+		}
+		return h.callbacks.OnFailed(ctx, &e)
+	default:
+		return fmt.Errorf("webhook: unknown event type %q", eventType)
+	}
+}
+
+// VerifySignature checks that sig is a valid HMAC-SHA256 signature of body
+// under secret. sig is the hex-encoded digest, as sent in HeaderSignature.
+func VerifySignature(secret string, body []byte, sig string) error {
+	if sig == "" {
+		return fmt.Errorf("webhook: missing %s header", HeaderSignature)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(sig)) {
+		return fmt.Errorf("webhook: signature mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func sign(t *testing.T, secret string, body []byte) string {
+	t.Helper()
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestHandlerServeHTTPDispatchesOnCompleted(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"request_id":"req_1","credits_used":5}`)
+
+	var gotCtx context.Context
+	var gotEvent *GenerationCompleted
+	h := NewHandler(secret, Callbacks{
+		OnCompleted: func(ctx context.Context, e *GenerationCompleted) error {
+			gotCtx = ctx
+			gotEvent = e
+			return nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/reve", strings.NewReader(string(body)))
+	req.Header.Set(HeaderEventType, string(EventGenerationCompleted))
+	req.Header.Set(HeaderSignature, sign(t, secret, body))
+	rec := httptest.NewRecorder()
+
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	if gotEvent == nil || gotEvent.RequestID != "req_1" {
+		t.Fatalf("OnCompleted event = %+v, want RequestID req_1", gotEvent)
+	}
+	if gotCtx == nil {
+		t.Error("OnCompleted context = nil, want the request's context")
+	}
+}
+
+func TestHandlerServeHTTPRejectsBadSignature(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"request_id":"req_1"}`)
+
+	called := false
+	h := NewHandler(secret, Callbacks{
+		OnCompleted: func(ctx context.Context, e *GenerationCompleted) error {
+			called = true
+			return nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/reve", strings.NewReader(string(body)))
+	req.Header.Set(HeaderEventType, string(EventGenerationCompleted))
+	req.Header.Set(HeaderSignature, "not-the-right-signature")
+	rec := httptest.NewRecorder()
+
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if called {
+		t.Error("OnCompleted was called despite a bad signature")
+	}
+}
+
+func TestHandlerServeHTTPPropagatesCallbackErrorAs500(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"request_id":"req_1","error_code":"oops","message":"boom"}`)
+
+	h := NewHandler(secret, Callbacks{
+		OnFailed: func(ctx context.Context, e *GenerationFailed) error {
+			return context.DeadlineExceeded
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/reve", strings.NewReader(string(body)))
+	req.Header.Set(HeaderEventType, string(EventGenerationFailed))
+	req.Header.Set(HeaderSignature, sign(t, secret, body))
+	rec := httptest.NewRecorder()
+
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestHandlerServeHTTPNilCallbackIsAcknowledged(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"request_id":"req_1"}`)
+
+	h := NewHandler(secret, Callbacks{})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/reve", strings.NewReader(string(body)))
+	req.Header.Set(HeaderEventType, string(EventGenerationCompleted))
+	req.Header.Set(HeaderSignature, sign(t, secret, body))
+	rec := httptest.NewRecorder()
+
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"request_id":"req_1"}`)
+	good := sign(t, secret, body)
+
+	if err := VerifySignature(secret, body, good); err != nil {
+		t.Errorf("VerifySignature() = %v, want nil", err)
+	}
+	if err := VerifySignature(secret, body, ""); err == nil {
+		t.Error("VerifySignature() with empty sig = nil, want error")
+	}
+	if err := VerifySignature(secret, body, "wrong"); err == nil {
+		t.Error("VerifySignature() with wrong sig = nil, want error")
+	}
+	if err := VerifySignature(secret, []byte("tampered"), good); err == nil {
+		t.Error("VerifySignature() with tampered body = nil, want error")
+	}
+}